@@ -8,10 +8,30 @@ import (
 
 // Admin represents an Admin in Intercom.
 type Admin struct {
-	ID    json.Number `json:"id"`
-	Type  string      `json:"type"`
-	Name  string      `json:"name"`
-	Email string      `json:"email"`
+	ID               json.Number   `json:"id"`
+	Type             string        `json:"type"`
+	Name             string        `json:"name"`
+	Email            string        `json:"email"`
+	Avatar           *UserAvatar   `json:"avatar,omitempty"`
+	TeamIDs          []json.Number `json:"team_ids,omitempty"`
+	AwayModeEnabled  bool          `json:"away_mode_enabled,omitempty"`
+	AwayModeReassign bool          `json:"away_mode_reassign,omitempty"`
+}
+
+// App represents the Intercom workspace an Admin belongs to.
+type App struct {
+	IDCode               string `json:"id_code"`
+	Name                 string `json:"name"`
+	Region               string `json:"region,omitempty"`
+	Timezone             string `json:"timezone,omitempty"`
+	CreatedAt            int64  `json:"created_at,omitempty"`
+	IdentityVerification bool   `json:"identity_verification,omitempty"`
+}
+
+// CurrentAdmin is the Admin, and their App, that the credentials in use belong to.
+type CurrentAdmin struct {
+	Admin Admin
+	App   App
 }
 
 // AdminList represents an object holding list of Admins
@@ -29,6 +49,42 @@ func (c *AdminService) List(ctx context.Context) (AdminList, error) {
 	return c.Repository.list(ctx)
 }
 
+// Find an Admin by their ID.
+func (c *AdminService) Find(ctx context.Context, id string) (Admin, error) {
+	return c.Repository.find(ctx, id)
+}
+
+// Me finds the Admin and App that the credentials in use belong to.
+func (c *AdminService) Me(ctx context.Context) (CurrentAdmin, error) {
+	return c.Repository.me(ctx)
+}
+
+// SetAway turns away mode on or off for an Admin. While away, new replies to
+// their Conversations are reassigned if reassign is set.
+func (c *AdminService) SetAway(ctx context.Context, id string, away, reassign bool) (Admin, error) {
+	return c.Repository.setAway(ctx, id, away, reassign)
+}
+
+// UnmarshalJSON decodes an Admin whose ID and Team IDs may be JSON numbers or strings,
+// which differs between API versions.
+func (a *Admin) UnmarshalJSON(b []byte) error {
+	type admin Admin
+	aux := struct {
+		*admin
+		ID      json.RawMessage   `json:"id"`
+		TeamIDs []json.RawMessage `json:"team_ids"`
+	}{admin: (*admin)(a)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	a.ID = json.Number(unmarshalID(aux.ID))
+	a.TeamIDs = nil
+	for _, id := range aux.TeamIDs {
+		a.TeamIDs = append(a.TeamIDs, json.Number(unmarshalID(id)))
+	}
+	return nil
+}
+
 // IsNobodyAdmin is a helper function to determine if the Admin is 'Nobody'.
 func (a Admin) IsNobodyAdmin() bool {
 	return a.Type == "nobody_admin"
@@ -45,3 +101,7 @@ func (a Admin) MessageAddress() MessageAddress {
 func (a Admin) String() string {
 	return fmt.Sprintf("[intercom] %s { id: %s name: %s, email: %s }", a.Type, a.ID, a.Name, a.Email)
 }
+
+func (a App) String() string {
+	return fmt.Sprintf("[intercom] app { id_code: %s name: %s, region: %s }", a.IDCode, a.Name, a.Region)
+}
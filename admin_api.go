@@ -3,6 +3,7 @@ package intercom
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/opensimsim/intercom-go/interfaces"
 )
@@ -10,6 +11,9 @@ import (
 // AdminRepository defines the interface for working with Admins through the API.
 type AdminRepository interface {
 	list(context.Context) (AdminList, error)
+	find(context.Context, string) (Admin, error)
+	me(context.Context) (CurrentAdmin, error)
+	setAway(context.Context, string, bool, bool) (Admin, error)
 }
 
 // AdminAPI implements AdminRepository
@@ -18,6 +22,7 @@ type AdminAPI struct {
 }
 
 func (api AdminAPI) list(ctx context.Context) (AdminList, error) {
+	ctx = interfaces.WithOperation(ctx, "admins", "list")
 	adminList := AdminList{}
 	data, err := api.httpClient.Get(ctx, "/admins", nil)
 	if err != nil {
@@ -26,3 +31,46 @@ func (api AdminAPI) list(ctx context.Context) (AdminList, error) {
 	err = json.Unmarshal(data, &adminList)
 	return adminList, err
 }
+
+type requestAway struct {
+	AwayModeEnabled  bool `json:"away_mode_enabled"`
+	AwayModeReassign bool `json:"away_mode_reassign"`
+}
+
+func (api AdminAPI) find(ctx context.Context, id string) (Admin, error) {
+	ctx = interfaces.WithOperation(ctx, "admins", "find")
+	return unmarshalToAdmin(api.httpClient.Get(ctx, fmt.Sprintf("/admins/%s", id), nil))
+}
+
+func (api AdminAPI) me(ctx context.Context) (CurrentAdmin, error) {
+	ctx = interfaces.WithOperation(ctx, "admins", "me")
+	current := CurrentAdmin{}
+	data, err := api.httpClient.Get(ctx, "/me", nil)
+	if err != nil {
+		return current, err
+	}
+	if err := json.Unmarshal(data, &current.Admin); err != nil {
+		return current, err
+	}
+	app := struct {
+		App App `json:"app"`
+	}{}
+	err = json.Unmarshal(data, &app)
+	current.App = app.App
+	return current, err
+}
+
+func (api AdminAPI) setAway(ctx context.Context, id string, away, reassign bool) (Admin, error) {
+	ctx = interfaces.WithOperation(ctx, "admins", "set_away")
+	request := requestAway{AwayModeEnabled: away, AwayModeReassign: reassign}
+	return unmarshalToAdmin(interfaces.Put(ctx, api.httpClient, fmt.Sprintf("/admins/%s/away", id), request))
+}
+
+func unmarshalToAdmin(data []byte, err error) (Admin, error) {
+	admin := Admin{}
+	if err != nil {
+		return admin, err
+	}
+	err = json.Unmarshal(data, &admin)
+	return admin, err
+}
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -8,22 +9,76 @@ import (
 func TestAdminAPIList(t *testing.T) {
 	http := TestAdminHTTPClient{fixtureFilename: "fixtures/admins.json", expectedURI: "/admins", t: t}
 	api := AdminAPI{httpClient: &http}
-	adminList, _ := api.list()
+	adminList, _ := api.list(context.Background())
 	if adminList.Admins[0].ID != "1" {
 		t.Errorf("ID was %s, expected 1", adminList.Admins[0].ID)
 	}
 }
 
+func TestAdminAPIFind(t *testing.T) {
+	http := TestAdminHTTPClient{fixtureFilename: "fixtures/admin.json", expectedURI: "/admins/1", t: t}
+	api := AdminAPI{httpClient: &http}
+	admin, err := api.find(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Error finding admin: %v", err)
+	}
+	if admin.ID != "1" || !admin.AwayModeEnabled || !admin.AwayModeReassign {
+		t.Errorf("Admin was %+v", admin)
+	}
+	if len(admin.TeamIDs) != 1 || admin.TeamIDs[0] != "814865" {
+		t.Errorf("TeamIDs were %v, expected [814865]", admin.TeamIDs)
+	}
+	if admin.Avatar == nil || admin.Avatar.ImageURL == "" {
+		t.Errorf("Avatar was not decoded")
+	}
+}
+
+func TestAdminAPIMe(t *testing.T) {
+	http := TestAdminHTTPClient{fixtureFilename: "fixtures/me.json", expectedURI: "/me", t: t}
+	api := AdminAPI{httpClient: &http}
+	me, err := api.me(context.Background())
+	if err != nil {
+		t.Fatalf("Error finding me: %v", err)
+	}
+	if me.Admin.ID != "1" || me.Admin.Email != "admin_a@example.io" {
+		t.Errorf("Admin was %+v", me.Admin)
+	}
+	if me.App.IDCode != "abc123" || me.App.Region != "EU" {
+		t.Errorf("App was %+v", me.App)
+	}
+}
+
+func TestAdminAPISetAway(t *testing.T) {
+	http := TestAdminHTTPClient{fixtureFilename: "fixtures/admin.json", expectedURI: "/admins/1/away", t: t}
+	api := AdminAPI{httpClient: &http}
+	admin, _ := api.setAway(context.Background(), "1", true, true)
+	if !admin.AwayModeEnabled {
+		t.Errorf("Admin was not away")
+	}
+	if body := http.lastBody.(requestAway); !body.AwayModeEnabled || !body.AwayModeReassign {
+		t.Errorf("Request was %+v", body)
+	}
+}
+
 type TestAdminHTTPClient struct {
 	TestHTTPClient
 	t               *testing.T
 	fixtureFilename string
 	expectedURI     string
+	lastBody        interface{}
+}
+
+func (t TestAdminHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestAdminHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+func (t *TestAdminHTTPClient) Put(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
 	}
+	t.lastBody = body
 	return ioutil.ReadFile(t.fixtureFilename)
 }
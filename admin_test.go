@@ -1,6 +1,10 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
 
 func TestNobodyAdmin(t *testing.T) {
 	admin := Admin{Type: "nobody_admin", ID: "123"}
@@ -25,16 +29,52 @@ func TestAdminMessageAddress(t *testing.T) {
 
 func TestAdminList(t *testing.T) {
 	adminService := AdminService{Repository: TestAdminAPI{t: t}}
-	adminList, _ := adminService.List()
+	adminList, _ := adminService.List(context.Background())
 	if adminList.Admins[0].ID != "213" {
 		t.Errorf("Admin not found")
 	}
 }
 
+func TestAdminFind(t *testing.T) {
+	adminService := AdminService{Repository: TestAdminAPI{t: t}}
+	admin, _ := adminService.Find(context.Background(), "213")
+	if admin.ID != "213" {
+		t.Errorf("Admin not found")
+	}
+}
+
+func TestAdminMe(t *testing.T) {
+	adminService := AdminService{Repository: TestAdminAPI{t: t}}
+	me, _ := adminService.Me(context.Background())
+	if me.Admin.ID != "213" || me.App.IDCode != "abc123" {
+		t.Errorf("Me was %+v", me)
+	}
+}
+
+func TestAdminSetAway(t *testing.T) {
+	adminService := AdminService{Repository: TestAdminAPI{t: t}}
+	admin, _ := adminService.SetAway(context.Background(), "213", true, false)
+	if !admin.AwayModeEnabled || admin.AwayModeReassign {
+		t.Errorf("Admin was %+v, expected away without reassignment", admin)
+	}
+}
+
 type TestAdminAPI struct {
 	t *testing.T
 }
 
-func (t TestAdminAPI) list() (AdminList, error) {
+func (t TestAdminAPI) list(ctx context.Context) (AdminList, error) {
 	return AdminList{Admins: []Admin{Admin{ID: "213"}}}, nil
 }
+
+func (t TestAdminAPI) find(ctx context.Context, id string) (Admin, error) {
+	return Admin{ID: json.Number(id)}, nil
+}
+
+func (t TestAdminAPI) me(ctx context.Context) (CurrentAdmin, error) {
+	return CurrentAdmin{Admin: Admin{ID: "213"}, App: App{IDCode: "abc123"}}, nil
+}
+
+func (t TestAdminAPI) setAway(ctx context.Context, id string, away, reassign bool) (Admin, error) {
+	return Admin{ID: json.Number(id), AwayModeEnabled: away, AwayModeReassign: reassign}, nil
+}
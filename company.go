@@ -8,6 +8,8 @@ import (
 // CompanyService handles interactions with the API through a CompanyRepository.
 type CompanyService struct {
 	Repository CompanyRepository
+	// DataAttributes, if set, validates custom attributes before they are saved.
+	DataAttributes *DataAttributeService
 }
 
 // CompanyList holds a list of Companies and paging information
@@ -120,8 +122,45 @@ func (c *CompanyService) Scroll(ctx context.Context, scrollParam string) (Compan
 	return c.Repository.scroll(ctx, scrollParam)
 }
 
+// IterateList iterates over all Companies, fetching each page from params.Page onwards.
+func (c *CompanyService) IterateList(ctx context.Context, params PageParams) *Iterator[Company] {
+	return newPageIterator(ctx, params, func(ctx context.Context, params PageParams) ([]Company, PageParams, error) {
+		companyList, err := c.List(ctx, params)
+		return companyList.Companies, companyList.Pages, err
+	})
+}
+
+// IterateScroll iterates over all Companies for App via Scroll API.
+func (c *CompanyService) IterateScroll(ctx context.Context) *Iterator[Company] {
+	return newScrollIterator(ctx, func(ctx context.Context, scrollParam string) ([]Company, string, error) {
+		companyList, err := c.Scroll(ctx, scrollParam)
+		return companyList.Companies, companyList.ScrollParam, err
+	})
+}
+
+// IterateUsersByID iterates over all Company Users by ID, fetching each page from params.Page onwards.
+func (c *CompanyService) IterateUsersByID(ctx context.Context, id string, params PageParams) *Iterator[User] {
+	return newPageIterator(ctx, params, func(ctx context.Context, params PageParams) ([]User, PageParams, error) {
+		userList, err := c.ListUsersByID(ctx, id, params)
+		return userList.Users, userList.Pages, err
+	})
+}
+
+// IterateUsersByCompanyID iterates over all Company Users by CompanyID, fetching each page from params.Page onwards.
+func (c *CompanyService) IterateUsersByCompanyID(ctx context.Context, companyID string, params PageParams) *Iterator[User] {
+	return newPageIterator(ctx, params, func(ctx context.Context, params PageParams) ([]User, PageParams, error) {
+		userList, err := c.ListUsersByCompanyID(ctx, companyID, params)
+		return userList.Users, userList.Pages, err
+	})
+}
+
 // Save a new Company, or update an existing one.
 func (c *CompanyService) Save(ctx context.Context, user *Company) (Company, error) {
+	if c.DataAttributes != nil {
+		if err := c.DataAttributes.ValidateCustomAttributes(ctx, MODEL_COMPANY, user.CustomAttributes); err != nil {
+			return Company{}, err
+		}
+	}
 	return c.Repository.save(ctx, user)
 }
 
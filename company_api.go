@@ -36,6 +36,7 @@ type requestCompany struct {
 }
 
 func (api CompanyAPI) find(ctx context.Context, params CompanyIdentifiers) (Company, error) {
+	ctx = interfaces.WithOperation(ctx, "companies", "find")
 	company := Company{}
 	data, err := api.getClientForFind(ctx, params)
 	if err != nil {
@@ -56,6 +57,7 @@ func (api CompanyAPI) getClientForFind(ctx context.Context, params CompanyIdenti
 }
 
 func (api CompanyAPI) list(ctx context.Context, params companyListParams) (CompanyList, error) {
+	ctx = interfaces.WithOperation(ctx, "companies", "list")
 	companyList := CompanyList{}
 	data, err := api.httpClient.Get(ctx, "/companies", params)
 	if err != nil {
@@ -66,6 +68,7 @@ func (api CompanyAPI) list(ctx context.Context, params companyListParams) (Compa
 }
 
 func (api CompanyAPI) listUsers(ctx context.Context, id string, params companyUserListParams) (UserList, error) {
+	ctx = interfaces.WithOperation(ctx, "companies", "list_users")
 	companyUserList := UserList{}
 	data, err := api.getClientForListUsers(ctx, id, params)
 	if err != nil {
@@ -86,6 +89,7 @@ func (api CompanyAPI) getClientForListUsers(ctx context.Context, id string, para
 }
 
 func (api CompanyAPI) scroll(ctx context.Context, scrollParam string) (CompanyList, error) {
+	ctx = interfaces.WithOperation(ctx, "companies", "scroll")
 	companyList := CompanyList{}
 	params := scrollParams{ScrollParam: scrollParam}
 	data, err := api.httpClient.Get(ctx, "/companies/scroll", params)
@@ -97,6 +101,7 @@ func (api CompanyAPI) scroll(ctx context.Context, scrollParam string) (CompanyLi
 }
 
 func (api CompanyAPI) save(ctx context.Context, company *Company) (Company, error) {
+	ctx = interfaces.WithOperation(ctx, "companies", "save")
 	requestCompany := requestCompany{
 		ID:               company.ID,
 		Name:             company.Name,
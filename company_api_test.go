@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -8,7 +9,7 @@ import (
 func TestCompanyAPIFind(t *testing.T) {
 	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company.json", expectedURI: "/companies/54c42e7ea7a765fa7", t: t}
 	api := CompanyAPI{httpClient: &http}
-	company, err := api.find(CompanyIdentifiers{ID: "54c42e7ea7a765fa7"})
+	company, err := api.find(context.Background(), CompanyIdentifiers{ID: "54c42e7ea7a765fa7"})
 	if err != nil {
 		t.Errorf("Error parsing fixture %s", err)
 	}
@@ -30,7 +31,7 @@ func TestCompanyAPIListUsers(t *testing.T) {
 	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/users.json", expectedURI: "/companies/54c42ed71623d8caa/users", t: t}
 	api := CompanyAPI{httpClient: &http}
 	params := companyUserListParams{Type: "user"}
-	companyUserList, err := api.listUsers("54c42ed71623d8caa", params)
+	companyUserList, err := api.listUsers(context.Background(), "54c42ed71623d8caa", params)
 	if err != nil {
 		t.Errorf("Error parsing fixture %s", err)
 	}
@@ -43,7 +44,7 @@ func TestCompanyAPIListUsers(t *testing.T) {
 func TestCompanyAPIFindByName(t *testing.T) {
 	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/company.json", expectedURI: "/companies", t: t}
 	api := CompanyAPI{httpClient: &http}
-	company, _ := api.find(CompanyIdentifiers{Name: "Important Company"})
+	company, _ := api.find(context.Background(), CompanyIdentifiers{Name: "Important Company"})
 	if company.Name != "Important Company" {
 		t.Errorf("Name was %s, expected Important Company", company.Name)
 	}
@@ -52,7 +53,7 @@ func TestCompanyAPIFindByName(t *testing.T) {
 func TestCompanyAPIListDefault(t *testing.T) {
 	http := TestCompanyHTTPClient{fixtureFilename: "fixtures/companies.json", expectedURI: "/companies", t: t}
 	api := CompanyAPI{httpClient: &http}
-	companyList, _ := api.list(companyListParams{})
+	companyList, _ := api.list(context.Background(), companyListParams{})
 	companies := companyList.Companies
 	if companies[0].ID != "54c42ed71623d8caa" {
 		t.Errorf("ID was %s, expected 54c42ed71623d8caa", companies[0].ID)
@@ -67,7 +68,7 @@ func TestCompanyAPISave(t *testing.T) {
 	http := TestCompanyHTTPClient{t: t, expectedURI: "/companies"}
 	api := CompanyAPI{httpClient: &http}
 	company := Company{CompanyID: "27"}
-	api.save(&company)
+	api.save(context.Background(), &company)
 }
 
 type TestCompanyHTTPClient struct {
@@ -77,14 +78,14 @@ type TestCompanyHTTPClient struct {
 	expectedURI     string
 }
 
-func (t TestCompanyHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+func (t TestCompanyHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestCompanyHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+func (t TestCompanyHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if uri != "/companies" {
 		t.t.Errorf("Wrong endpoint called")
 	}
@@ -1,32 +1,33 @@
 package intercom
 
 import (
+	"context"
 	"testing"
 )
 
 func TestCompanyFindByID(t *testing.T) {
-	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByID("46adad3f09126dca")
+	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByID(context.Background(), "46adad3f09126dca")
 	if company.ID != "46adad3f09126dca" {
 		t.Errorf("Company not found")
 	}
 }
 
 func TestCompanyFindByName(t *testing.T) {
-	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByName("My Co")
+	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByName(context.Background(), "My Co")
 	if company.Name != "My Co" {
 		t.Errorf("Company not found")
 	}
 }
 
 func TestCompanyFindByCompanyID(t *testing.T) {
-	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByCompanyID("134d")
+	company, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).FindByCompanyID(context.Background(), "134d")
 	if company.CompanyID != "134d" {
 		t.Errorf("Company not found")
 	}
 }
 
 func TestCompanyList(t *testing.T) {
-	companyList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).List(PageParams{})
+	companyList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).List(context.Background(), PageParams{})
 	companies := companyList.Companies
 	if companies[0].ID != "46adad3f09126dca" {
 		t.Errorf("Company not listed")
@@ -34,7 +35,7 @@ func TestCompanyList(t *testing.T) {
 }
 
 func TestCompanyListUsersByID(t *testing.T) {
-	companyUserList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListUsersByID("46adad3f09126dca", PageParams{})
+	companyUserList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListUsersByID(context.Background(), "46adad3f09126dca", PageParams{})
 	users := companyUserList.Users
 	if users[0].Companies.Companies[0].ID != "46adad3f09126dca" {
 		t.Errorf("User not listed")
@@ -42,7 +43,7 @@ func TestCompanyListUsersByID(t *testing.T) {
 }
 
 func TestCompanyListUsersByCompanyID(t *testing.T) {
-	companyUserList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListUsersByCompanyID("134d", PageParams{})
+	companyUserList, _ := (&CompanyService{Repository: TestCompanyAPI{t: t}}).ListUsersByCompanyID(context.Background(), "134d", PageParams{})
 	users := companyUserList.Users
 	if users[0].Companies.Companies[0].CompanyID != "134d" {
 		t.Errorf("User not listed")
@@ -52,30 +53,30 @@ func TestCompanyListUsersByCompanyID(t *testing.T) {
 func TestCompanySave(t *testing.T) {
 	companyService := CompanyService{Repository: TestCompanyAPI{t: t}}
 	company := Company{ID: "46adad3f09126dca", CustomAttributes: map[string]interface{}{"is_cool": true}}
-	companyService.Save(&company)
+	companyService.Save(context.Background(), &company)
 }
 
 type TestCompanyAPI struct {
 	t *testing.T
 }
 
-func (t TestCompanyAPI) find(params CompanyIdentifiers) (Company, error) {
+func (t TestCompanyAPI) find(ctx context.Context, params CompanyIdentifiers) (Company, error) {
 	return Company{ID: params.ID, Name: params.Name, CompanyID: params.CompanyID}, nil
 }
 
-func (t TestCompanyAPI) list(params companyListParams) (CompanyList, error) {
+func (t TestCompanyAPI) list(ctx context.Context, params companyListParams) (CompanyList, error) {
 	return CompanyList{Companies: []Company{Company{ID: "46adad3f09126dca", Name: "My Co", CompanyID: "aa123"}}}, nil
 }
 
-func (t TestCompanyAPI) listUsers(id string, params companyUserListParams) (UserList, error) {
+func (t TestCompanyAPI) listUsers(ctx context.Context, id string, params companyUserListParams) (UserList, error) {
 	return UserList{Users: []User{User{Companies: &CompanyList{Companies: []Company{Company{ID: id, CompanyID: params.CompanyID}}}}}}, nil
 }
 
-func (t TestCompanyAPI) scroll(scrollParam string) (CompanyList, error) {
+func (t TestCompanyAPI) scroll(ctx context.Context, scrollParam string) (CompanyList, error) {
 	return CompanyList{Companies: []Company{Company{ID: "46adad3f09126dca", Name: "My Co", CompanyID: "aa123"}}}, nil
 }
 
-func (t TestCompanyAPI) save(company *Company) (Company, error) {
+func (t TestCompanyAPI) save(ctx context.Context, company *Company) (Company, error) {
 	if company.ID != "46adad3f09126dca" {
 		t.t.Errorf("Company ID was %s, expected 46adad3f09126dca", company.ID)
 	}
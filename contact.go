@@ -15,6 +15,8 @@ type ContactList struct {
 	Pages       PageParams
 	Contacts    []Contact
 	ScrollParam string `json:"scroll_param,omitempty"`
+	// TotalCount is the number of Contacts matching a search.
+	TotalCount int64 `json:"total_count,omitempty"`
 }
 
 // Contact represents a Contact within Intercom.
@@ -75,6 +77,45 @@ func (c *ContactService) Scroll(ctx context.Context, scrollParam string) (Contac
 	return c.Repository.scroll(ctx, scrollParam)
 }
 
+// IterateList iterates over all Contacts for App, fetching each page from params.Page onwards.
+func (c *ContactService) IterateList(ctx context.Context, params PageParams) *Iterator[Contact] {
+	return newPageIterator(ctx, params, func(ctx context.Context, params PageParams) ([]Contact, PageParams, error) {
+		contactList, err := c.List(ctx, params)
+		return contactList.Contacts, contactList.Pages, err
+	})
+}
+
+// IterateScroll iterates over all Contacts for App via Scroll API.
+func (c *ContactService) IterateScroll(ctx context.Context) *Iterator[Contact] {
+	return newScrollIterator(ctx, func(ctx context.Context, scrollParam string) ([]Contact, string, error) {
+		contactList, err := c.Scroll(ctx, scrollParam)
+		return contactList.Contacts, contactList.ScrollParam, err
+	})
+}
+
+// Search Contacts by standard and custom attributes:
+//
+//  search := intercom.NewSearch(intercom.Field("custom_attributes.plan").Eq("pro").
+//    And(intercom.Field("last_request_at").GreaterThan(time.Now().AddDate(0, -1, 0))))
+//
+// Results include both Users and leads, converted to Contacts with their ExternalID as UserID.
+// Pages.Next.StartingAfter is the cursor for the next page of results. Requires API version 2.0 or newer.
+func (c *ContactService) Search(ctx context.Context, search *SearchRequest) (ContactList, error) {
+	return c.Repository.search(ctx, search)
+}
+
+// IterateSearch iterates over all Contacts matching a search, from search.Pagination.StartingAfter onwards.
+func (c *ContactService) IterateSearch(ctx context.Context, search *SearchRequest) *Iterator[Contact] {
+	search = search.copy()
+	return newCursorIterator(ctx, func(ctx context.Context, cursor string) ([]Contact, string, error) {
+		if cursor != "" {
+			search.StartingAfter(cursor)
+		}
+		contactList, err := c.Search(ctx, search)
+		return contactList.Contacts, nextCursor(contactList.Pages), err
+	})
+}
+
 // ListByEmail looks up a list of Contacts by their Email.
 func (c *ContactService) ListByEmail(ctx context.Context, email string, params PageParams) (ContactList, error) {
 	return c.Repository.list(ctx, contactListParams{PageParams: params, Email: email})
@@ -14,6 +14,7 @@ type ContactRepository interface {
 	find(context.Context, UserIdentifiers) (Contact, error)
 	list(context.Context, contactListParams) (ContactList, error)
 	scroll(context.Context, string) (ContactList, error)
+	search(context.Context, *SearchRequest) (ContactList, error)
 	create(context.Context, *Contact) (Contact, error)
 	update(context.Context, *Contact) (Contact, error)
 	convert(context.Context, *Contact, *User) (User, error)
@@ -26,6 +27,7 @@ type ContactAPI struct {
 }
 
 func (api ContactAPI) find(ctx context.Context, params UserIdentifiers) (Contact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "find")
 	return unmarshalToContact(api.getClientForFind(ctx, params))
 }
 
@@ -40,6 +42,7 @@ func (api ContactAPI) getClientForFind(ctx context.Context, params UserIdentifie
 }
 
 func (api ContactAPI) list(ctx context.Context, params contactListParams) (ContactList, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "list")
 	contactList := ContactList{}
 	data, err := api.httpClient.Get(ctx, "/contacts", params)
 	if err != nil {
@@ -50,6 +53,7 @@ func (api ContactAPI) list(ctx context.Context, params contactListParams) (Conta
 }
 
 func (api ContactAPI) scroll(ctx context.Context, scrollParam string) (ContactList, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "scroll")
 	contactList := ContactList{}
 	params := scrollParams{ScrollParam: scrollParam}
 	data, err := api.httpClient.Get(ctx, "/contacts/scroll", params)
@@ -60,17 +64,35 @@ func (api ContactAPI) scroll(ctx context.Context, scrollParam string) (ContactLi
 	return contactList, err
 }
 
+func (api ContactAPI) search(ctx context.Context, search *SearchRequest) (ContactList, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "search")
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/contacts/search"); err != nil {
+		return ContactList{}, err
+	}
+	data, err := api.httpClient.Post(ctx, "/contacts/search", search)
+	if err != nil {
+		return ContactList{}, err
+	}
+	// Searches return unified Contacts, of both roles, as in API version 2.0.
+	unifiedList := UnifiedContactList{}
+	err = json.Unmarshal(data, &unifiedList)
+	return ContactList{Pages: unifiedList.Pages, Contacts: toContacts(unifiedList.Contacts), TotalCount: unifiedList.TotalCount}, err
+}
+
 func (api ContactAPI) create(ctx context.Context, contact *Contact) (Contact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "create")
 	requestContact := api.buildRequestContact(contact)
 	return unmarshalToContact(api.httpClient.Post(ctx, "/contacts", &requestContact))
 }
 
 func (api ContactAPI) update(ctx context.Context, contact *Contact) (Contact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "update")
 	requestContact := api.buildRequestContact(contact)
 	return unmarshalToContact(api.httpClient.Post(ctx, "/contacts", &requestContact))
 }
 
 func (api ContactAPI) convert(ctx context.Context, contact *Contact, user *User) (User, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "convert")
 	cr := convertRequest{Contact: api.buildRequestContact(contact), User: requestUser{
 		ID:         user.ID,
 		UserID:     user.UserID,
@@ -81,6 +103,7 @@ func (api ContactAPI) convert(ctx context.Context, contact *Contact, user *User)
 }
 
 func (api ContactAPI) delete(ctx context.Context, id string) (Contact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "delete")
 	contact := Contact{}
 	data, err := api.httpClient.Delete(ctx, fmt.Sprintf("/contacts/%s", id), nil)
 	if err != nil {
@@ -1,11 +1,14 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestContactAPIFind(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact.json", expectedURI: "/contacts/54c42e7ea7a765fa7", t: t}
 	api := ContactAPI{httpClient: &http}
-	contact, err := api.find(UserIdentifiers{ID: "54c42e7ea7a765fa7"})
+	contact, err := api.find(context.Background(), UserIdentifiers{ID: "54c42e7ea7a765fa7"})
 	if err != nil {
 		t.Errorf("Error parsing fixture %s", err)
 	}
@@ -23,7 +26,7 @@ func TestContactAPIFind(t *testing.T) {
 func TestContactAPIListDefault(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contacts.json", expectedURI: "/contacts", t: t}
 	api := ContactAPI{httpClient: &http}
-	contactList, _ := api.list(contactListParams{})
+	contactList, _ := api.list(context.Background(), contactListParams{})
 	contacts := contactList.Contacts
 	if contacts[0].ID != "54c42e7ea7a765fa7" {
 		t.Errorf("ID was %s, expected 54c42e7ea7a765fa7", contacts[0].ID)
@@ -37,7 +40,7 @@ func TestContactAPIListDefault(t *testing.T) {
 func TestContactAPIListByEmail(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contacts.json", expectedURI: "/contacts", t: t}
 	api := ContactAPI{httpClient: &http}
-	contactList, _ := api.list(contactListParams{Email: "mycontact@example.io"})
+	contactList, _ := api.list(context.Background(), contactListParams{Email: "mycontact@example.io"})
 	contacts := contactList.Contacts
 	if contacts[0].ID != "54c42e7ea7a765fa7" {
 		t.Errorf("ID was %s, expected 54c42e7ea7a765fa7", contacts[0].ID)
@@ -51,18 +54,37 @@ func TestContactAPIListByEmail(t *testing.T) {
 	}
 }
 
+func TestContactAPISearch(t *testing.T) {
+	http := TestUserHTTPClient{fixtureFilename: "fixtures/contacts_search.json", expectedURI: "/contacts/search", t: t}
+	api := ContactAPI{httpClient: &http}
+	search := NewSearch(Field("custom_attributes.plan").Eq("pro").And(Field("email").StartsWith("my"))).PerPage(1)
+	contactList, err := api.search(context.Background(), search)
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if len(contactList.Contacts) != 1 || contactList.TotalCount != 2 {
+		t.Fatalf("Contacts were %v, total %d", contactList.Contacts, contactList.TotalCount)
+	}
+	if contact := contactList.Contacts[0]; contact.ID != "54c42e7ea7a765fa7" || contact.UserID != "123" || contact.LastRequestAt != 1421676990 {
+		t.Errorf("Contact was %+v", contact)
+	}
+	if contactList.Pages.Next == nil || contactList.Pages.Next.StartingAfter != "WzE0MjE2NzY5ODAwMDAsIjU0YzQyZTdlYTdhNzY1ZmE3Il0=" {
+		t.Errorf("Next page cursor not retrieved, %v", contactList.Pages.Next)
+	}
+}
+
 func TestContactAPICreate(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact.json", expectedURI: "/contacts", t: t}
 	api := ContactAPI{httpClient: &http}
 	contact := &Contact{Email: "mycontact@example.io"}
-	api.create(contact)
+	api.create(context.Background(), contact)
 }
 
 func TestContactAPIUpdate(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact.json", expectedURI: "/contacts", t: t}
 	api := ContactAPI{httpClient: &http}
 	contact := &Contact{UserID: "123", Email: "mycontact@example.io"}
-	api.update(contact)
+	api.update(context.Background(), contact)
 }
 
 func TestContactAPIConvert(t *testing.T) {
@@ -70,7 +92,7 @@ func TestContactAPIConvert(t *testing.T) {
 	api := ContactAPI{httpClient: &http}
 	contact := &Contact{UserID: "abc", Email: "mycontact@example.io"}
 	user := &User{UserID: "123"}
-	returned, _ := api.convert(contact, user)
+	returned, _ := api.convert(context.Background(), contact, user)
 	if returned.UserID != "123" {
 		t.Errorf("Expected UserID %s, got %s", "123", returned.UserID)
 	}
@@ -80,7 +102,7 @@ func TestContactAPIDelete(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/contact.json", expectedURI: "/contacts/b123d", t: t}
 	api := ContactAPI{httpClient: &http}
 	contact := &Contact{ID: "b123d"}
-	returned, _ := api.delete(contact.ID)
+	returned, _ := api.delete(context.Background(), contact.ID)
 	if returned.UserID != "123" {
 		t.Errorf("Expected UserID %s, got %s", "123", returned.UserID)
 	}
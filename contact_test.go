@@ -1,27 +1,28 @@
 package intercom
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pborman/uuid"
 )
 
 func TestContactFindByID(t *testing.T) {
-	contact, _ := (&ContactService{Repository: TestContactAPI{t: t}}).FindByID("46adad3f09126dca")
+	contact, _ := (&ContactService{Repository: TestContactAPI{t: t}}).FindByID(context.Background(), "46adad3f09126dca")
 	if contact.ID != "46adad3f09126dca" {
 		t.Errorf("Contact not found")
 	}
 }
 
 func TestContactFindByUserID(t *testing.T) {
-	contact, _ := (&ContactService{Repository: TestContactAPI{t: t}}).FindByUserID("134d")
+	contact, _ := (&ContactService{Repository: TestContactAPI{t: t}}).FindByUserID(context.Background(), "134d")
 	if contact.UserID != "134d" {
 		t.Errorf("Contact not found")
 	}
 }
 
 func TestContactList(t *testing.T) {
-	contactList, _ := (&ContactService{Repository: TestContactAPI{t: t}}).ListByEmail("jamie@example.io", PageParams{})
+	contactList, _ := (&ContactService{Repository: TestContactAPI{t: t}}).ListByEmail(context.Background(), "jamie@example.io", PageParams{})
 	contacts := contactList.Contacts
 	if contacts[0].ID != "46adad3f09126dca" {
 		t.Errorf("Contact not listed")
@@ -29,17 +30,25 @@ func TestContactList(t *testing.T) {
 }
 
 func TestContactListEmail(t *testing.T) {
-	contactList, _ := (&ContactService{Repository: TestContactAPI{t: t}}).List(PageParams{})
+	contactList, _ := (&ContactService{Repository: TestContactAPI{t: t}}).List(context.Background(), PageParams{})
 	contacts := contactList.Contacts
 	if contacts[0].ID != "46adad3f09126dca" {
 		t.Errorf("Contact not listed")
 	}
 }
 
+func TestContactSearch(t *testing.T) {
+	search := NewSearch(Field("custom_attributes.plan").Eq("pro"))
+	contactList, _ := (&ContactService{Repository: TestContactAPI{t: t}}).Search(context.Background(), search)
+	if contactList.Contacts[0].ID != "46adad3f09126dca" || contactList.TotalCount != 1 {
+		t.Errorf("Contact not found")
+	}
+}
+
 func TestContactCreate(t *testing.T) {
 	contactService := ContactService{Repository: TestContactAPI{t: t}}
 	contact := Contact{Email: "some@email.com"}
-	c, _ := contactService.Create(&contact)
+	c, _ := contactService.Create(context.Background(), &contact)
 	if c.Email != contact.Email {
 		t.Errorf("expected returned contact to have email %s, got %s", contact.Email, c.Email)
 	}
@@ -48,7 +57,7 @@ func TestContactCreate(t *testing.T) {
 func TestContactUpdate(t *testing.T) {
 	contactService := ContactService{Repository: TestContactAPI{t: t}}
 	contact := Contact{Email: "some@email.com"}
-	c, _ := contactService.Update(&contact)
+	c, _ := contactService.Update(context.Background(), &contact)
 	if c.Email != contact.Email {
 		t.Errorf("expected returned contact to have email %s, got %s", contact.Email, c.Email)
 	}
@@ -58,7 +67,7 @@ func TestContactConvert(t *testing.T) {
 	contactService := ContactService{Repository: TestContactAPI{t: t}}
 	contact := Contact{UserID: "aaaa", Email: "some@email.com"}
 	user := User{ID: "abc13", UserID: "c135"}
-	u, _ := contactService.Convert(&contact, &user)
+	u, _ := contactService.Convert(context.Background(), &contact, &user)
 	if u.Email != contact.Email {
 		t.Errorf("expected returned user to have email %s, got %s", contact.Email, u.Email)
 	}
@@ -70,7 +79,7 @@ func TestContactConvert(t *testing.T) {
 func TestContactDelete(t *testing.T) {
 	contactService := ContactService{Repository: TestContactAPI{t: t}}
 	contact := Contact{UserID: "aaaa", Email: "some@email.com"}
-	contactService.Delete(&contact)
+	contactService.Delete(context.Background(), &contact)
 }
 
 func TestContactMessageAddress(t *testing.T) {
@@ -94,30 +103,37 @@ type TestContactAPI struct {
 	t *testing.T
 }
 
-func (t TestContactAPI) find(params UserIdentifiers) (Contact, error) {
+func (t TestContactAPI) find(ctx context.Context, params UserIdentifiers) (Contact, error) {
 	return Contact{ID: params.ID, Email: params.Email, UserID: params.UserID}, nil
 }
 
-func (t TestContactAPI) list(params contactListParams) (ContactList, error) {
+func (t TestContactAPI) list(ctx context.Context, params contactListParams) (ContactList, error) {
 	return ContactList{Contacts: []Contact{Contact{ID: "46adad3f09126dca", Email: "jamie@example.io", UserID: "aa123"}}}, nil
 }
 
-func (t TestContactAPI) scroll(scrollParam string) (ContactList, error) {
+func (t TestContactAPI) scroll(ctx context.Context, scrollParam string) (ContactList, error) {
 	return ContactList{Contacts: []Contact{Contact{ID: "46adad3f09126dca", Email: "jamie@example.io", UserID: "aa123"}}}, nil
 }
 
-func (t TestContactAPI) create(c *Contact) (Contact, error) {
+func (t TestContactAPI) search(ctx context.Context, search *SearchRequest) (ContactList, error) {
+	if search.Query.Field != "custom_attributes.plan" {
+		t.t.Errorf("Search was %v", search)
+	}
+	return ContactList{Contacts: []Contact{Contact{ID: "46adad3f09126dca"}}, TotalCount: 1}, nil
+}
+
+func (t TestContactAPI) create(ctx context.Context, c *Contact) (Contact, error) {
 	return Contact{ID: c.ID, Email: c.Email, UserID: uuid.New()}, nil
 }
 
-func (t TestContactAPI) update(c *Contact) (Contact, error) {
+func (t TestContactAPI) update(ctx context.Context, c *Contact) (Contact, error) {
 	return Contact{ID: c.ID, Email: c.Email, UserID: c.UserID}, nil
 }
 
-func (t TestContactAPI) convert(c *Contact, u *User) (User, error) {
+func (t TestContactAPI) convert(ctx context.Context, c *Contact, u *User) (User, error) {
 	return User{ID: u.ID, Email: c.Email, UserID: u.UserID}, nil
 }
 
-func (t TestContactAPI) delete(id string) (Contact, error) {
+func (t TestContactAPI) delete(ctx context.Context, id string) (Contact, error) {
 	return Contact{ID: id}, nil
 }
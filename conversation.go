@@ -1,6 +1,13 @@
 package intercom
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // ConversationService handles interactions with the API through an ConversationRepository.
 type ConversationService struct {
@@ -11,6 +18,8 @@ type ConversationService struct {
 type ConversationList struct {
 	Pages         PageParams     `json:"pages"`
 	Conversations []Conversation `json:"conversations"`
+	// TotalCount is the number of Conversations matching a search.
+	TotalCount int64 `json:"total_count,omitempty"`
 }
 
 // A Conversation represents a conversation between users and admins in Intercom.
@@ -20,7 +29,11 @@ type Conversation struct {
 	UpdatedAt           int64                `json:"updated_at"`
 	User                User                 `json:"user"`
 	Assignee            Admin                `json:"assignee"`
+	TeamAssigneeID      string               `json:"team_assignee_id,omitempty"`
 	Open                bool                 `json:"open"`
+	State               string               `json:"state,omitempty"`
+	SnoozedUntil        int64                `json:"snoozed_until,omitempty"`
+	Priority            string               `json:"priority,omitempty"`
 	Read                bool                 `json:"read"`
 	ConversationMessage ConversationMessage  `json:"conversation_message"`
 	ConversationParts   ConversationPartList `json:"conversation_parts"`
@@ -29,11 +42,12 @@ type Conversation struct {
 
 // A ConversationMessage is the message that started the conversation rendered for presentation
 type ConversationMessage struct {
-	ID      string         `json:"id"`
-	Subject string         `json:"subject"`
-	Body    string         `json:"body"`
-	Author  MessageAddress `json:"author"`
-	URL     string         `json:"url"`
+	ID          string         `json:"id"`
+	Subject     string         `json:"subject"`
+	Body        string         `json:"body"`
+	Author      MessageAddress `json:"author"`
+	URL         string         `json:"url"`
+	Attachments []Attachment   `json:"attachments,omitempty"`
 }
 
 // A ConversationPartList lists the subsequent Conversation Parts
@@ -43,14 +57,99 @@ type ConversationPartList struct {
 
 // A ConversationPart is a Reply, Note, or Assignment to a Conversation
 type ConversationPart struct {
-	ID         string         `json:"id"`
-	PartType   string         `json:"part_type"`
-	Body       string         `json:"body"`
-	CreatedAt  int64          `json:"created_at"`
-	UpdatedAt  int64          `json:"updated_at"`
-	NotifiedAt int64          `json:"notified_at"`
-	AssignedTo Admin          `json:"assigned_to"`
-	Author     MessageAddress `json:"author"`
+	ID          string         `json:"id"`
+	PartType    string         `json:"part_type"`
+	Body        string         `json:"body"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	NotifiedAt  int64          `json:"notified_at"`
+	AssignedTo  Admin          `json:"assigned_to"`
+	Author      MessageAddress `json:"author"`
+	Attachments []Attachment   `json:"attachments,omitempty"`
+	ExternalID  string         `json:"external_id,omitempty"`
+	// Redacted is set once the part's body has been redacted.
+	Redacted bool `json:"redacted"`
+}
+
+// An Attachment is a file attached to a ConversationMessage or ConversationPart
+type Attachment struct {
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Filesize    int64  `json:"filesize,omitempty"`
+	Width       int64  `json:"width,omitempty"`
+	Height      int64  `json:"height,omitempty"`
+}
+
+type conversationRequest struct {
+	From MessageAddress `json:"from"`
+	Body string         `json:"body"`
+}
+
+// A Redaction identifies the ConversationPart or source message to redact.
+type Redaction struct {
+	Type               string `json:"type"`
+	ConversationID     string `json:"conversation_id"`
+	ConversationPartID string `json:"conversation_part_id,omitempty"`
+	SourceID           string `json:"source_id,omitempty"`
+}
+
+// RedactPart creates a Redaction of a ConversationPart.
+func RedactPart(conversationID, partID string) Redaction {
+	return Redaction{Type: "conversation_part", ConversationID: conversationID, ConversationPartID: partID}
+}
+
+// RedactSource creates a Redaction of the source message of a Conversation.
+func RedactSource(conversationID, sourceID string) Redaction {
+	return Redaction{Type: "source", ConversationID: conversationID, SourceID: sourceID}
+}
+
+// UnmarshalJSON decodes a Conversation from either the 1.x or 2.x API shape.
+// From 2.x, source, contacts, admin_assignee_id and state are used in place of
+// conversation_message, user, assignee and open. team_assignee_id may be a number or string.
+func (c *Conversation) UnmarshalJSON(b []byte) error {
+	type conversation Conversation
+	aux := struct {
+		*conversation
+		Open            *bool                `json:"open"`
+		State           string               `json:"state"`
+		Source          *ConversationMessage `json:"source"`
+		AdminAssigneeID json.RawMessage      `json:"admin_assignee_id"`
+		TeamAssigneeID  json.RawMessage      `json:"team_assignee_id"`
+		Contacts        *struct {
+			Contacts []MessageAddress `json:"contacts"`
+		} `json:"contacts"`
+	}{conversation: (*conversation)(c)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	c.State = aux.State
+	if aux.Open != nil {
+		c.Open = *aux.Open
+	} else {
+		c.Open = aux.State == "open"
+	}
+	if aux.Source != nil && c.ConversationMessage.ID == "" {
+		c.ConversationMessage = *aux.Source
+	}
+	if id := unmarshalID(aux.AdminAssigneeID); id != "" && c.Assignee.ID == "" {
+		c.Assignee = Admin{ID: json.Number(id), Type: "admin"}
+	}
+	c.TeamAssigneeID = unmarshalID(aux.TeamAssigneeID)
+	if aux.Contacts != nil && len(aux.Contacts.Contacts) > 0 && c.User.ID == "" {
+		c.User = User{ID: aux.Contacts.Contacts[0].ID}
+	}
+	return nil
+}
+
+// unmarshalID decodes an ID that may be a JSON number, string, or null.
+func unmarshalID(raw json.RawMessage) string {
+	id := string(raw)
+	if id == "null" {
+		return ""
+	}
+	return strings.Trim(id, `"`)
 }
 
 // The state of Conversations to query
@@ -72,6 +171,38 @@ func (c *ConversationService) ListAll(ctx context.Context, pageParams PageParams
 	return c.Repository.list(ctx, ConversationListParams{PageParams: pageParams})
 }
 
+// IterateAll iterates over all Conversations, fetching each page from pageParams.Page onwards.
+func (c *ConversationService) IterateAll(ctx context.Context, pageParams PageParams) *Iterator[Conversation] {
+	return newPageIterator(ctx, pageParams, func(ctx context.Context, params PageParams) ([]Conversation, PageParams, error) {
+		convoList, err := c.ListAll(ctx, params)
+		return convoList.Conversations, convoList.Pages, err
+	})
+}
+
+// Search Conversations, such as those open and assigned to an Admin:
+//
+//  search := intercom.NewSearch(intercom.And(
+//    intercom.Field("open").Eq(true),
+//    intercom.Field("admin_assignee_id").Eq(admin.ID),
+//  )).SortBy("created_at", intercom.SORT_DESCENDING).PerPage(50)
+//
+// Pages.Next.StartingAfter is the cursor for the next page of results. Requires API version 2.0 or newer.
+func (c *ConversationService) Search(ctx context.Context, search *SearchRequest) (ConversationList, error) {
+	return c.Repository.search(ctx, search)
+}
+
+// IterateSearch iterates over all Conversations matching a search, from search.Pagination.StartingAfter onwards.
+func (c *ConversationService) IterateSearch(ctx context.Context, search *SearchRequest) *Iterator[Conversation] {
+	search = search.copy()
+	return newCursorIterator(ctx, func(ctx context.Context, cursor string) ([]Conversation, string, error) {
+		if cursor != "" {
+			search.StartingAfter(cursor)
+		}
+		convoList, err := c.Search(ctx, search)
+		return convoList.Conversations, nextCursor(convoList.Pages), err
+	})
+}
+
 // List Conversations by Admin
 func (c *ConversationService) ListByAdmin(ctx context.Context, admin *Admin, state ConversationListState, pageParams PageParams) (ConversationList, error) {
 	params := ConversationListParams{
@@ -114,22 +245,18 @@ func (c *ConversationService) MarkRead(ctx context.Context, id string) (Conversa
 }
 
 func (c *ConversationService) Reply(ctx context.Context, id string, author MessagePerson, replyType ReplyType, body string) (Conversation, error) {
-	return c.reply(ctx, id, author, replyType, body, nil)
+	return c.reply(ctx, id, author, Reply{ReplyType: replyType.String(), Body: body})
 }
 
 // Reply to a Conversation by id
 func (c *ConversationService) ReplyWithAttachmentURLs(ctx context.Context, id string, author MessagePerson, replyType ReplyType, body string, attachmentURLs []string) (Conversation, error) {
-	return c.reply(ctx, id, author, replyType, body, attachmentURLs)
+	return c.reply(ctx, id, author, Reply{ReplyType: replyType.String(), Body: body, AttachmentURLs: attachmentURLs})
 }
 
-func (c *ConversationService) reply(ctx context.Context, id string, author MessagePerson, replyType ReplyType, body string, attachmentURLs []string) (Conversation, error) {
+// reply sends the Reply from the author, setting its type and author identifiers.
+func (c *ConversationService) reply(ctx context.Context, id string, author MessagePerson, reply Reply) (Conversation, error) {
 	addr := author.MessageAddress()
-	reply := Reply{
-		Type:           addr.Type,
-		ReplyType:      replyType.String(),
-		Body:           body,
-		AttachmentURLs: attachmentURLs,
-	}
+	reply.Type = addr.Type
 	if addr.Type == "admin" {
 		reply.AdminID = addr.ID
 	} else {
@@ -140,27 +267,94 @@ func (c *ConversationService) reply(ctx context.Context, id string, author Messa
 	return c.Repository.reply(ctx, id, &reply)
 }
 
-// Assign a Conversation to an Admin
+// Assign a Conversation to an Admin, or unassign it if the assignee is the nobody_admin
 func (c *ConversationService) Assign(ctx context.Context, id string, assigner, assignee *Admin) (Conversation, error) {
-	assignerAddr := assigner.MessageAddress()
-	assigneeAddr := assignee.MessageAddress()
+	assigneeID := assignee.MessageAddress().ID
+	if assignee.IsNobodyAdmin() {
+		assigneeID = "0"
+	}
+	return c.assign(ctx, id, assigner, "admin", assigneeID)
+}
+
+// AssignToTeam assigns a Conversation to a Team, then to an Admin if one is given, in two requests.
+// If assigning the Admin fails, the Conversation stays assigned to the Team, and is returned
+// with an error wrapping the Admin assignment's. Requires API version 2.0 or newer.
+func (c *ConversationService) AssignToTeam(ctx context.Context, id string, assigner *Admin, team *Team, assignee *Admin) (Conversation, error) {
+	convo, err := c.assign(ctx, id, assigner, "team", team.ID.String())
+	if err != nil || assignee == nil {
+		return convo, err
+	}
+	assigned, err := c.Assign(ctx, id, assigner, assignee)
+	if err != nil {
+		return convo, fmt.Errorf("[intercom] conversation %s assigned to team %s, but not to admin %s: %w", id, team.ID, assignee.ID, err)
+	}
+	return assigned, nil
+}
+
+// Unassign a Conversation from its Admin, assigning it to the nobody_admin. Its Team is unchanged.
+func (c *ConversationService) Unassign(ctx context.Context, id string, assigner *Admin) (Conversation, error) {
+	return c.Assign(ctx, id, assigner, &Admin{Type: "nobody_admin"})
+}
+
+func (c *ConversationService) assign(ctx context.Context, id string, assigner *Admin, assigneeType, assigneeID string) (Conversation, error) {
 	reply := Reply{
-		Type:       "admin",
+		Type:       assigneeType,
 		ReplyType:  CONVERSATION_ASSIGN.String(),
-		AdminID:    assignerAddr.ID,
-		AssigneeID: assigneeAddr.ID,
+		AdminID:    assigner.MessageAddress().ID,
+		AssigneeID: assigneeID,
 	}
 	return c.Repository.reply(ctx, id, &reply)
 }
 
 // Open a Conversation (without a body)
 func (c *ConversationService) Open(ctx context.Context, id string, opener *Admin) (Conversation, error) {
-	return c.reply(ctx, id, opener, CONVERSATION_OPEN, "", nil)
+	return c.reply(ctx, id, opener, Reply{ReplyType: CONVERSATION_OPEN.String()})
 }
 
 // Close a Conversation (without a body)
 func (c *ConversationService) Close(ctx context.Context, id string, closer *Admin) (Conversation, error) {
-	return c.reply(ctx, id, closer, CONVERSATION_CLOSE, "", nil)
+	return c.reply(ctx, id, closer, Reply{ReplyType: CONVERSATION_CLOSE.String()})
+}
+
+// Create a Conversation started by a message from a User or Contact. Requires API version 2.0 or newer.
+func (c *ConversationService) Create(ctx context.Context, from MessagePerson, body string) (Conversation, error) {
+	return c.Repository.create(ctx, &conversationRequest{From: from.MessageAddress(), Body: body})
+}
+
+// Redact a ConversationPart or the source message of a Conversation, such as one containing
+// card details, returning the Conversation. Requires API version 2.0 or newer.
+//
+//  convo, err := ic.Conversations.Redact(ctx, intercom.RedactPart(convo.ID, part.ID))
+func (c *ConversationService) Redact(ctx context.Context, redaction Redaction) (Conversation, error) {
+	return c.Repository.redact(ctx, &redaction)
+}
+
+// Snooze a Conversation until a time in the future, when it reopens. Requires API version 2.0 or newer.
+func (c *ConversationService) Snooze(ctx context.Context, id string, snoozer *Admin, until time.Time) (Conversation, error) {
+	if !until.After(time.Now()) {
+		return Conversation{}, errors.New("Snooze time must be in the future")
+	}
+	return c.reply(ctx, id, snoozer, Reply{ReplyType: CONVERSATION_SNOOZE.String(), SnoozedUntil: until.Unix()})
+}
+
+// Unsnooze a snoozed Conversation, which is the same as opening it
+func (c *ConversationService) Unsnooze(ctx context.Context, id string, unsnoozer *Admin) (Conversation, error) {
+	return c.Open(ctx, id, unsnoozer)
+}
+
+// MarkPriority marks a Conversation as priority, or not
+func (c *ConversationService) MarkPriority(ctx context.Context, id string, admin *Admin, priority bool) (Conversation, error) {
+	replyType := CONVERSATION_PRIORITY
+	if !priority {
+		replyType = CONVERSATION_NOT_PRIORITY
+	}
+	return c.reply(ctx, id, admin, Reply{ReplyType: replyType.String()})
+}
+
+// RunAssignmentRules assigns a Conversation according to the workspace's assignment rules.
+// Requires API version 2.0 or newer.
+func (c *ConversationService) RunAssignmentRules(ctx context.Context, id string) (Conversation, error) {
+	return c.Repository.runAssignmentRules(ctx, id)
 }
 
 type ConversationListParams struct {
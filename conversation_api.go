@@ -3,6 +3,7 @@ package intercom
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/opensimsim/intercom-go/interfaces"
@@ -14,6 +15,10 @@ type ConversationRepository interface {
 	list(context.Context, ConversationListParams) (ConversationList, error)
 	read(context.Context, string) (Conversation, error)
 	reply(context.Context, string, *Reply) (Conversation, error)
+	search(context.Context, *SearchRequest) (ConversationList, error)
+	runAssignmentRules(context.Context, string) (Conversation, error)
+	create(context.Context, *conversationRequest) (Conversation, error)
+	redact(context.Context, *Redaction) (Conversation, error)
 }
 
 // ConversationAPI implements ConversationRepository
@@ -26,6 +31,7 @@ type conversationReadRequest struct {
 }
 
 func (api ConversationAPI) list(ctx context.Context, params ConversationListParams) (ConversationList, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "list")
 	convoList := ConversationList{}
 	data, err := api.httpClient.Get(ctx, "/conversations", params)
 	if err != nil {
@@ -36,6 +42,7 @@ func (api ConversationAPI) list(ctx context.Context, params ConversationListPara
 }
 
 func (api ConversationAPI) read(ctx context.Context, id string) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "read")
 	conversation := Conversation{}
 	data, err := api.httpClient.Post(ctx, fmt.Sprintf("/conversations/%s", id), conversationReadRequest{Read: true})
 	if err != nil {
@@ -46,16 +53,24 @@ func (api ConversationAPI) read(ctx context.Context, id string) (Conversation, e
 }
 
 func (api ConversationAPI) reply(ctx context.Context, id string, reply *Reply) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "reply")
 	conversation := Conversation{}
-	data, err := api.httpClient.Post(ctx, fmt.Sprintf("/conversations/%s/reply", id), reply)
+	path := fmt.Sprintf("/conversations/%s/reply", id)
+	if reply.ReplyType == CONVERSATION_SNOOZE.String() || reply.Type == "team" {
+		if err := requireVersion(api.httpClient, "2.0", "POST", path); err != nil {
+			return conversation, err
+		}
+	}
+	data, err := api.httpClient.Post(ctx, path, reply)
 	if err != nil {
 		return conversation, err
 	}
 	err = json.Unmarshal(data, &conversation)
-	return conversation, nil
+	return conversation, err
 }
 
 func (api ConversationAPI) find(ctx context.Context, id string) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "find")
 	conversation := Conversation{}
 	data, err := api.httpClient.Get(ctx, fmt.Sprintf("/conversations/%s", id), nil)
 	if err != nil {
@@ -64,3 +79,66 @@ func (api ConversationAPI) find(ctx context.Context, id string) (Conversation, e
 	err = json.Unmarshal(data, &conversation)
 	return conversation, err
 }
+
+func (api ConversationAPI) search(ctx context.Context, search *SearchRequest) (ConversationList, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "search")
+	convoList := ConversationList{}
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/conversations/search"); err != nil {
+		return convoList, err
+	}
+	data, err := api.httpClient.Post(ctx, "/conversations/search", search)
+	if err != nil {
+		return convoList, err
+	}
+	err = json.Unmarshal(data, &convoList)
+	return convoList, err
+}
+
+func (api ConversationAPI) runAssignmentRules(ctx context.Context, id string) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "run_assignment_rules")
+	conversation := Conversation{}
+	path := fmt.Sprintf("/conversations/%s/run_assignment_rules", id)
+	if err := requireVersion(api.httpClient, "2.0", "POST", path); err != nil {
+		return conversation, err
+	}
+	data, err := api.httpClient.Post(ctx, path, nil)
+	if err != nil {
+		return conversation, err
+	}
+	err = json.Unmarshal(data, &conversation)
+	return conversation, err
+}
+
+// create starts the Conversation, then finds it, as the API responds with the message that started it.
+func (api ConversationAPI) create(ctx context.Context, request *conversationRequest) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "create")
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/conversations"); err != nil {
+		return Conversation{}, err
+	}
+	data, err := api.httpClient.Post(ctx, "/conversations", request)
+	if err != nil {
+		return Conversation{}, err
+	}
+	message := MessageResponse{}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return Conversation{}, err
+	}
+	if message.ConversationID == "" {
+		return Conversation{}, errors.New("Missing Conversation ID in response")
+	}
+	return api.find(ctx, message.ConversationID)
+}
+
+func (api ConversationAPI) redact(ctx context.Context, redaction *Redaction) (Conversation, error) {
+	ctx = interfaces.WithOperation(ctx, "conversations", "redact")
+	conversation := Conversation{}
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/conversations/redact"); err != nil {
+		return conversation, err
+	}
+	data, err := api.httpClient.Post(ctx, "/conversations/redact", redaction)
+	if err != nil {
+		return conversation, err
+	}
+	err = json.Unmarshal(data, &conversation)
+	return conversation, err
+}
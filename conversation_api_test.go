@@ -1,14 +1,18 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
+	"time"
+
+	"github.com/opensimsim/intercom-go/interfaces"
 )
 
 func TestConversationFind(t *testing.T) {
 	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147", fixtureFilename: "fixtures/conversation.json"}
 	api := ConversationAPI{httpClient: &http}
-	convo, _ := api.find("147")
+	convo, _ := api.find(context.Background(), "147")
 	if convo.ID != "147" {
 		t.Errorf("Conversation not retrieved, %s", convo.ID)
 	}
@@ -21,6 +25,10 @@ func TestConversationFind(t *testing.T) {
 	if convo.ConversationMessage.URL != "/the/page/url.html" {
 		t.Errorf("Conversation URL not retrieved, %s", convo.ConversationMessage.URL)
 	}
+	part := convo.ConversationParts.Parts[0]
+	if len(part.Attachments) != 1 || part.Attachments[0].ContentType != "image/png" || part.ExternalID != "msg-1" || part.Redacted {
+		t.Errorf("Conversation part was %+v", part)
+	}
 }
 
 func TestConversationRead(t *testing.T) {
@@ -32,7 +40,7 @@ func TestConversationRead(t *testing.T) {
 		}
 	}
 	api := ConversationAPI{httpClient: &http}
-	convo, err := api.read("147")
+	convo, err := api.read(context.Background(), "147")
 	if err != nil {
 		t.Errorf("%v", err)
 	}
@@ -50,7 +58,7 @@ func TestConversationReply(t *testing.T) {
 		}
 	}
 	api := ConversationAPI{httpClient: &http}
-	convo, err := api.reply("147", &Reply{ReplyType: CONVERSATION_NOTE.String(), AdminID: "123"})
+	convo, err := api.reply(context.Background(), "147", &Reply{ReplyType: CONVERSATION_NOTE.String(), AdminID: "123"})
 	if err != nil {
 		t.Errorf("%v", err)
 	}
@@ -68,7 +76,7 @@ func TestConversationReplyWithAttachment(t *testing.T) {
 		}
 	}
 	api := ConversationAPI{httpClient: &http}
-	convo, err := api.reply("147", &Reply{ReplyType: CONVERSATION_COMMENT.String(), AdminID: "123", AttachmentURLs: []string{"http://www.example.com/attachment.jpg"}})
+	convo, err := api.reply(context.Background(), "147", &Reply{ReplyType: CONVERSATION_COMMENT.String(), AdminID: "123", AttachmentURLs: []string{"http://www.example.com/attachment.jpg"}})
 	if err != nil {
 		t.Errorf("%v", err)
 	}
@@ -80,7 +88,7 @@ func TestConversationReplyWithAttachment(t *testing.T) {
 func TestConversationListAll(t *testing.T) {
 	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations", fixtureFilename: "fixtures/conversations.json"}
 	api := ConversationAPI{httpClient: &http}
-	convos, _ := api.list(ConversationListParams{})
+	convos, _ := api.list(context.Background(), ConversationListParams{})
 	if convos.Conversations[0].ID != "147" {
 		t.Errorf("Conversation not retrieved")
 	}
@@ -107,7 +115,7 @@ func TestConversationListUserUnread(t *testing.T) {
 		}
 	}
 	api := ConversationAPI{httpClient: &http}
-	api.list(ConversationListParams{Unread: Bool(true)})
+	api.list(context.Background(), ConversationListParams{Unread: Bool(true)})
 }
 
 func TestConversationListAdminOpen(t *testing.T) {
@@ -119,7 +127,78 @@ func TestConversationListAdminOpen(t *testing.T) {
 		}
 	}
 	api := ConversationAPI{httpClient: &http}
-	api.list(ConversationListParams{Open: Bool(true)})
+	api.list(context.Background(), ConversationListParams{Open: Bool(true)})
+}
+
+func TestConversationRunAssignmentRules(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/147/run_assignment_rules", fixtureFilename: "fixtures/conversation.json"}
+	api := ConversationAPI{httpClient: &http}
+	convo, err := api.runAssignmentRules(context.Background(), "147")
+	if err != nil || convo.ID != "147" {
+		t.Errorf("Conversation was %v, error %v", convo, err)
+	}
+}
+
+func TestConversationRedact(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/redact", fixtureFilename: "fixtures/conversation.json"}
+	http.testFunc = func(t *testing.T, body interface{}) {
+		if redaction := body.(*Redaction); redaction.ConversationPartID != "4412" {
+			t.Errorf("Redaction was %+v", redaction)
+		}
+	}
+	api := ConversationAPI{httpClient: &http}
+	convo, err := api.redact(context.Background(), &Redaction{Type: "conversation_part", ConversationID: "147", ConversationPartID: "4412"})
+	if err != nil || convo.ID != "147" {
+		t.Errorf("Conversation was %v, error %v", convo, err)
+	}
+}
+
+func TestConversationSearch(t *testing.T) {
+	http := TestConversationHTTPClient{t: t, expectedURI: "/conversations/search", fixtureFilename: "fixtures/conversations_search.json"}
+	http.testFunc = func(t *testing.T, body interface{}) {
+		search := body.(*SearchRequest)
+		if search.Pagination.PerPage != 1 {
+			t.Errorf("Search was not paginated")
+		}
+	}
+	api := ConversationAPI{httpClient: &http}
+	convos, err := api.search(context.Background(), NewSearch(Field("state").Eq("open")).PerPage(1))
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+	if convos.Conversations[0].ID != "147" || convos.TotalCount != 2 {
+		t.Errorf("Conversations not retrieved")
+	}
+	if convos.Pages.Next == nil || convos.Pages.Next.StartingAfter != "WzE0MDA4NTA5NzMwMDAsMTQ3LDJd" {
+		t.Errorf("Next page cursor not retrieved, %v", convos.Pages.Next)
+	}
+}
+
+func TestConversationSearchRequiresVersion(t *testing.T) {
+	ic := NewClient("a", "b")
+	ic.Option(APIVersion("1.4"))
+	_, err := ic.Conversations.Search(context.Background(), NewSearch(Field("state").Eq("open")))
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Error was %v, expected a VersionError", err)
+	}
+}
+
+func TestConversationSnoozeAndAssignmentRequireVersion(t *testing.T) {
+	ic := NewClient("a", "b")
+	ic.Option(APIVersion("1.4"))
+	ctx := context.Background()
+	_, err := ic.Conversations.Snooze(ctx, "147", &Admin{ID: "5"}, time.Now().Add(time.Hour))
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Snooze error was %v, expected a VersionError", err)
+	}
+	_, err = ic.Conversations.RunAssignmentRules(ctx, "147")
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("RunAssignmentRules error was %v, expected a VersionError", err)
+	}
+	_, err = ic.Conversations.AssignToTeam(ctx, "147", &Admin{ID: "5"}, &Team{ID: "9"}, nil)
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("AssignToTeam error was %v, expected a VersionError", err)
+	}
 }
 
 type TestConversationHTTPClient struct {
@@ -131,7 +210,7 @@ type TestConversationHTTPClient struct {
 	lastQueryParams interface{}
 }
 
-func (t *TestConversationHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+func (t *TestConversationHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
 	if t.testFunc != nil {
 		t.testFunc(t.t, queryParams)
 	}
@@ -141,7 +220,7 @@ func (t *TestConversationHTTPClient) Get(uri string, queryParams interface{}) ([
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t *TestConversationHTTPClient) Post(uri string, dataObject interface{}) ([]byte, error) {
+func (t *TestConversationHTTPClient) Post(ctx context.Context, uri string, dataObject interface{}) ([]byte, error) {
 	if t.testFunc != nil {
 		t.testFunc(t.t, dataObject)
 	}
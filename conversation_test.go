@@ -1,10 +1,18 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
 
 func TestFindConversation(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
-	convo, _ := conversationService.Find("123")
+	convo, _ := conversationService.Find(context.Background(), "123")
 	if convo.ID != "123" {
 		t.Errorf("Did not receive conversation")
 	}
@@ -12,7 +20,7 @@ func TestFindConversation(t *testing.T) {
 
 func TestReadConversation(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
-	convo, _ := conversationService.MarkRead("123")
+	convo, _ := conversationService.MarkRead(context.Background(), "123")
 	if convo.ID != "123" {
 		t.Errorf("Did not receive conversation")
 	}
@@ -29,7 +37,7 @@ func TestReplyConversationComment(t *testing.T) {
 		}
 	}
 	conversationService := ConversationService{Repository: testAPI}
-	conversationService.Reply("123", &User{ID: "abc123"}, CONVERSATION_COMMENT, "Body")
+	conversationService.Reply(context.Background(), "123", &User{ID: "abc123"}, CONVERSATION_COMMENT, "Body")
 }
 
 func TestReplyConversationCommentWithAttachment(t *testing.T) {
@@ -43,7 +51,7 @@ func TestReplyConversationCommentWithAttachment(t *testing.T) {
 		}
 	}
 	conversationService := ConversationService{Repository: testAPI}
-	conversationService.ReplyWithAttachmentURLs("123", &User{ID: "abc123"}, CONVERSATION_COMMENT, "Body", []string{"http://www.example.com/attachment.jpg"})
+	conversationService.ReplyWithAttachmentURLs(context.Background(), "123", &User{ID: "abc123"}, CONVERSATION_COMMENT, "Body", []string{"http://www.example.com/attachment.jpg"})
 }
 
 func TestReplyConversationOpen(t *testing.T) {
@@ -57,7 +65,7 @@ func TestReplyConversationOpen(t *testing.T) {
 		}
 	}
 	conversationService := ConversationService{Repository: testAPI}
-	conversationService.Reply("123", &User{ID: "abc123"}, CONVERSATION_OPEN, "Body")
+	conversationService.Reply(context.Background(), "123", &User{ID: "abc123"}, CONVERSATION_OPEN, "Body")
 }
 
 func TestReplyConversationNote(t *testing.T) {
@@ -71,7 +79,7 @@ func TestReplyConversationNote(t *testing.T) {
 		}
 	}
 	conversationService := ConversationService{Repository: testAPI}
-	conversationService.Reply("123", &Admin{ID: "abc123"}, CONVERSATION_NOTE, "Body")
+	conversationService.Reply(context.Background(), "123", &Admin{ID: "abc123"}, CONVERSATION_NOTE, "Body")
 }
 
 func TestAssignConversation(t *testing.T) {
@@ -88,12 +96,67 @@ func TestAssignConversation(t *testing.T) {
 		}
 	}
 	conversationService := ConversationService{Repository: testAPI}
-	conversationService.Assign("123", &Admin{ID: "abc123"}, &Admin{ID: "def789"})
+	conversationService.Assign(context.Background(), "123", &Admin{ID: "abc123"}, &Admin{ID: "def789"})
+}
+
+func TestAssignConversationToTeam(t *testing.T) {
+	replies := []*Reply{}
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		replies = append(replies, reply.(*Reply))
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.AssignToTeam(context.Background(), "123", &Admin{ID: "abc123"}, &Team{ID: "814865"}, &Admin{ID: "def789"})
+	if len(replies) != 2 {
+		t.Fatalf("%d assignments made, expected 2", len(replies))
+	}
+	if replies[0].Type != "team" || replies[0].AssigneeID != "814865" || replies[0].AdminID != "abc123" {
+		t.Errorf("team assignment was %+v", replies[0])
+	}
+	if replies[1].Type != "admin" || replies[1].AssigneeID != "def789" {
+		t.Errorf("admin assignment was %+v", replies[1])
+	}
+}
+
+func TestAssignConversationToTeamOnly(t *testing.T) {
+	replies := 0
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		replies++
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.AssignToTeam(context.Background(), "123", &Admin{ID: "abc123"}, &Team{ID: "814865"}, nil)
+	if replies != 1 {
+		t.Errorf("%d assignments made, expected 1", replies)
+	}
+}
+
+func TestAssignConversationToTeamPartialFailure(t *testing.T) {
+	conversationService := ConversationService{Repository: TestAdminAssignmentFailingAPI{TestConversationAPI{t: t}}}
+	convo, err := conversationService.AssignToTeam(context.Background(), "123", &Admin{ID: "abc123"}, &Team{ID: "814865"}, &Admin{ID: "def789"})
+	var herr interfaces.HTTPError
+	if !errors.As(err, &herr) || herr.Code != "not_found" {
+		t.Errorf("Error was %v, expected the wrapped admin assignment error", err)
+	}
+	if convo.TeamAssigneeID != "814865" {
+		t.Errorf("Conversation was %+v, expected the team assignment", convo)
+	}
+}
+
+func TestUnassignConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, reply interface{}) {
+		if reply.(*Reply).Type != "admin" || reply.(*Reply).AssigneeID != "0" {
+			t.Errorf("unassignment was %+v, expected admin assignee 0", reply)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.Unassign(context.Background(), "123", &Admin{ID: "abc123"})
 }
 
 func TestListAllConversations(t *testing.T) {
 	conversationService := ConversationService{Repository: TestConversationAPI{t: t}}
-	list, _ := conversationService.ListAll(PageParams{})
+	list, _ := conversationService.ListAll(context.Background(), PageParams{})
 	if list.Conversations[0].ID != "123" {
 		t.Errorf("did not receive conversation")
 	}
@@ -108,7 +171,7 @@ func TestListUserConversationsUnread(t *testing.T) {
 	}
 	conversationService := ConversationService{Repository: testAPI}
 	user := User{}
-	list, _ := conversationService.ListByUser(&user, SHOW_UNREAD, PageParams{})
+	list, _ := conversationService.ListByUser(context.Background(), &user, SHOW_UNREAD, PageParams{})
 	if list.Conversations[0].ID != "123" {
 		t.Errorf("did not receive conversation")
 	}
@@ -123,7 +186,7 @@ func TestListUserConversationsAll(t *testing.T) {
 	}
 	conversationService := ConversationService{Repository: testAPI}
 	user := User{}
-	list, _ := conversationService.ListByUser(&user, SHOW_ALL, PageParams{})
+	list, _ := conversationService.ListByUser(context.Background(), &user, SHOW_ALL, PageParams{})
 	if list.Conversations[0].ID != "123" {
 		t.Errorf("did not receive conversation")
 	}
@@ -138,7 +201,7 @@ func TestListAdminConversationsAll(t *testing.T) {
 	}
 	conversationService := ConversationService{Repository: testAPI}
 	admin := Admin{}
-	list, _ := conversationService.ListByAdmin(&admin, SHOW_ALL, PageParams{})
+	list, _ := conversationService.ListByAdmin(context.Background(), &admin, SHOW_ALL, PageParams{})
 	if list.Conversations[0].ID != "123" {
 		t.Errorf("did not receive conversation")
 	}
@@ -153,35 +216,198 @@ func TestListAdminConversationsOpen(t *testing.T) {
 	}
 	conversationService := ConversationService{Repository: testAPI}
 	admin := Admin{}
-	list, _ := conversationService.ListByAdmin(&admin, SHOW_OPEN, PageParams{})
+	list, _ := conversationService.ListByAdmin(context.Background(), &admin, SHOW_OPEN, PageParams{})
 	if list.Conversations[0].ID != "123" {
 		t.Errorf("did not receive conversation")
 	}
 }
 
+func TestSearchConversations(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		search := params.(*SearchRequest)
+		if search.Query.Field != "state" || search.Sort.Order != SORT_DESCENDING {
+			t.Errorf("search was %v", search)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	search := NewSearch(Field("state").Eq("open")).SortBy("created_at", SORT_DESCENDING)
+	list, _ := conversationService.Search(context.Background(), search)
+	if list.Conversations[0].ID != "123" || list.TotalCount != 1 {
+		t.Errorf("did not receive conversation")
+	}
+}
+
+func TestIterateSearchFollowsCursor(t *testing.T) {
+	api := &TestSearchConversationAPI{pages: 3}
+	search := NewSearch(Field("open").Eq(true)).PerPage(2)
+	it := (&ConversationService{Repository: api}).IterateSearch(context.Background(), search)
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if it.Err() != nil {
+		t.Errorf("Err was %v, expected none", it.Err())
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("IDs were %v", ids)
+	}
+	if fmt.Sprint(api.cursors) != "[ cursor-1 cursor-2]" {
+		t.Errorf("Cursors were %q", api.cursors)
+	}
+	if search.Pagination.StartingAfter != "" {
+		t.Errorf("Search was changed by iterating, starting after %s", search.Pagination.StartingAfter)
+	}
+}
+
+type TestSearchConversationAPI struct {
+	TestConversationAPI
+	pages   int
+	cursors []string
+}
+
+func (t *TestSearchConversationAPI) search(ctx context.Context, search *SearchRequest) (ConversationList, error) {
+	t.cursors = append(t.cursors, search.Pagination.StartingAfter)
+	page := len(t.cursors)
+	list := ConversationList{Conversations: []Conversation{{ID: fmt.Sprint(page)}}, TotalCount: int64(t.pages)}
+	if page < t.pages {
+		list.Pages.Next = &PageCursor{Page: int64(page + 1), StartingAfter: fmt.Sprintf("cursor-%d", page)}
+	}
+	return list, nil
+}
+
+func TestSnoozeConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	until := time.Now().Add(time.Hour)
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		reply := params.(*Reply)
+		if reply.ReplyType != "snoozed" || reply.SnoozedUntil != until.Unix() || reply.AdminID != "5" || reply.Type != "admin" {
+			t.Errorf("Reply was %+v", reply)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.Snooze(context.Background(), "123", &Admin{ID: "5"}, until)
+}
+
+func TestSnoozeConversationInThePast(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		t.Errorf("Snoozed with %+v", params)
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	for _, until := range []time.Time{{}, time.Now().Add(-time.Minute)} {
+		if _, err := conversationService.Snooze(context.Background(), "123", &Admin{ID: "5"}, until); err == nil {
+			t.Errorf("Snoozed until %v", until)
+		}
+	}
+}
+
+func TestUnsnoozeAndPriorityConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	replyTypes := []string{}
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		replyTypes = append(replyTypes, params.(*Reply).ReplyType)
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	admin := &Admin{ID: "5"}
+	conversationService.Unsnooze(context.Background(), "123", admin)
+	conversationService.MarkPriority(context.Background(), "123", admin, true)
+	conversationService.MarkPriority(context.Background(), "123", admin, false)
+	if fmt.Sprint(replyTypes) != "[open priority not_priority]" {
+		t.Errorf("Reply types were %v", replyTypes)
+	}
+}
+
+func TestCreateConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		request := params.(*conversationRequest)
+		if request.From.Type != "user" || request.From.UserID != "27" || request.Body != "Hello" {
+			t.Errorf("Request was %+v", request)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	convo, _ := conversationService.Create(context.Background(), &User{UserID: "27"}, "Hello")
+	if convo.ID != "123" {
+		t.Errorf("did not receive conversation")
+	}
+}
+
+func TestRedactConversation(t *testing.T) {
+	testAPI := TestConversationAPI{t: t}
+	testAPI.testFunc = func(t *testing.T, params interface{}) {
+		redaction := params.(*Redaction)
+		if *redaction != (Redaction{Type: "conversation_part", ConversationID: "123", ConversationPartID: "456"}) {
+			t.Errorf("Redaction was %+v", redaction)
+		}
+	}
+	conversationService := ConversationService{Repository: testAPI}
+	conversationService.Redact(context.Background(), RedactPart("123", "456"))
+	if source := RedactSource("123", "789"); source.Type != "source" || source.SourceID != "789" {
+		t.Errorf("Source redaction was %+v", source)
+	}
+}
+
 type TestConversationAPI struct {
 	testFunc func(t *testing.T, params interface{})
 	t        *testing.T
 }
 
-func (t TestConversationAPI) list(params ConversationListParams) (ConversationList, error) {
+func (t TestConversationAPI) list(ctx context.Context, params ConversationListParams) (ConversationList, error) {
 	if t.testFunc != nil {
 		t.testFunc(t.t, params)
 	}
 	return ConversationList{Conversations: []Conversation{Conversation{ID: "123"}}, Pages: PageParams{Page: 1, PerPage: 20}}, nil
 }
 
-func (t TestConversationAPI) find(id string) (Conversation, error) {
+func (t TestConversationAPI) find(ctx context.Context, id string) (Conversation, error) {
 	return Conversation{ID: "123"}, nil
 }
 
-func (t TestConversationAPI) read(id string) (Conversation, error) {
+func (t TestConversationAPI) read(ctx context.Context, id string) (Conversation, error) {
 	return Conversation{ID: "123"}, nil
 }
 
-func (t TestConversationAPI) reply(id string, reply *Reply) (Conversation, error) {
+func (t TestConversationAPI) reply(ctx context.Context, id string, reply *Reply) (Conversation, error) {
 	if t.testFunc != nil {
 		t.testFunc(t.t, reply)
 	}
 	return Conversation{ID: "123"}, nil
 }
+
+// TestAdminAssignmentFailingAPI assigns Conversations to Teams, but fails to assign them to Admins.
+type TestAdminAssignmentFailingAPI struct {
+	TestConversationAPI
+}
+
+func (t TestAdminAssignmentFailingAPI) reply(ctx context.Context, id string, reply *Reply) (Conversation, error) {
+	if reply.Type == "admin" {
+		return Conversation{}, interfaces.HTTPError{StatusCode: 404, Code: "not_found"}
+	}
+	return Conversation{ID: id, TeamAssigneeID: reply.AssigneeID}, nil
+}
+
+func (t TestConversationAPI) search(ctx context.Context, search *SearchRequest) (ConversationList, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, search)
+	}
+	return ConversationList{Conversations: []Conversation{Conversation{ID: "123"}}, TotalCount: 1}, nil
+}
+
+func (t TestConversationAPI) runAssignmentRules(ctx context.Context, id string) (Conversation, error) {
+	return Conversation{ID: id}, nil
+}
+
+func (t TestConversationAPI) create(ctx context.Context, request *conversationRequest) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, request)
+	}
+	return Conversation{ID: "123"}, nil
+}
+
+func (t TestConversationAPI) redact(ctx context.Context, redaction *Redaction) (Conversation, error) {
+	if t.testFunc != nil {
+		t.testFunc(t.t, redaction)
+	}
+	return Conversation{ID: redaction.ConversationID}, nil
+}
@@ -0,0 +1,134 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// CountService handles interactions with the API through a CountRepository.
+type CountService struct {
+	Repository CountRepository
+}
+
+// AppCount holds the total number of each resource in the App.
+type AppCount struct {
+	Company int64 `json:"company"`
+	Lead    int64 `json:"lead"`
+	Segment int64 `json:"segment"`
+	Tag     int64 `json:"tag"`
+	User    int64 `json:"user"`
+}
+
+// NamedCount is the number of Users or Companies in a Segment, Tag or Company, identified by its name.
+// Counts of Users in each Company also have the Company's CompanyID as RemoteCompanyID.
+type NamedCount struct {
+	Name            string `json:"name"`
+	Count           int64  `json:"count"`
+	RemoteCompanyID string `json:"remote_company_id,omitempty"`
+}
+
+// ConversationCount holds the number of Conversations in each state.
+type ConversationCount struct {
+	Open       int64 `json:"open"`
+	Closed     int64 `json:"closed"`
+	Assigned   int64 `json:"assigned"`
+	Unassigned int64 `json:"unassigned"`
+}
+
+// AdminConversationCount holds the number of open and closed Conversations assigned to an Admin.
+type AdminConversationCount struct {
+	ID     json.Number `json:"id"`
+	Name   string      `json:"name"`
+	Email  string      `json:"email"`
+	Open   int64       `json:"open"`
+	Closed int64       `json:"closed"`
+}
+
+type countParams struct {
+	Type  string `url:"type,omitempty"`
+	Count string `url:"count,omitempty"`
+}
+
+// App counts the Companies, Leads, Segments, Tags and Users in the App.
+func (c *CountService) App(ctx context.Context) (AppCount, error) {
+	return c.Repository.app(ctx)
+}
+
+// UsersBySegment counts the Users in each Segment.
+func (c *CountService) UsersBySegment(ctx context.Context) ([]NamedCount, error) {
+	return c.Repository.list(ctx, countParams{Type: "user", Count: "segment"})
+}
+
+// UsersByTag counts the Users with each Tag.
+func (c *CountService) UsersByTag(ctx context.Context) ([]NamedCount, error) {
+	return c.Repository.list(ctx, countParams{Type: "user", Count: "tag"})
+}
+
+// CompaniesBySegment counts the Companies in each Segment.
+func (c *CountService) CompaniesBySegment(ctx context.Context) ([]NamedCount, error) {
+	return c.Repository.list(ctx, countParams{Type: "company", Count: "segment"})
+}
+
+// CompaniesByTag counts the Companies with each Tag.
+func (c *CountService) CompaniesByTag(ctx context.Context) ([]NamedCount, error) {
+	return c.Repository.list(ctx, countParams{Type: "company", Count: "tag"})
+}
+
+// UsersByCompany counts the Users in each Company.
+func (c *CountService) UsersByCompany(ctx context.Context) ([]NamedCount, error) {
+	return c.Repository.list(ctx, countParams{Type: "company", Count: "user"})
+}
+
+// Conversations counts the open, closed, assigned and unassigned Conversations.
+func (c *CountService) Conversations(ctx context.Context) (ConversationCount, error) {
+	return c.Repository.conversations(ctx)
+}
+
+// ConversationsByAdmin counts the open and closed Conversations assigned to each Admin.
+func (c *CountService) ConversationsByAdmin(ctx context.Context) ([]AdminConversationCount, error) {
+	return c.Repository.conversationsByAdmin(ctx)
+}
+
+// UnmarshalJSON decodes an AppCount from the count of each resource, such as {"user": {"count": 5}}.
+func (a *AppCount) UnmarshalJSON(b []byte) error {
+	type count struct {
+		Count int64 `json:"count"`
+	}
+	aux := struct {
+		Company, Lead, Segment, Tag, User count
+	}{}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	*a = AppCount{Company: aux.Company.Count, Lead: aux.Lead.Count, Segment: aux.Segment.Count, Tag: aux.Tag.Count, User: aux.User.Count}
+	return nil
+}
+
+// unmarshalNamedCounts decodes NamedCounts from a list of single entry objects, such as [{"Active": 5}],
+// or for Companies, [{"Acme": {"count": 7, "remote_company_id": "6"}}].
+func unmarshalNamedCounts(b []byte) ([]NamedCount, error) {
+	entries := []map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	counts := []NamedCount{}
+	for _, entry := range entries {
+		for name, value := range entry {
+			count := NamedCount{Name: name}
+			if len(value) > 0 && value[0] == '{' {
+				aux := struct {
+					Count           int64  `json:"count"`
+					RemoteCompanyID string `json:"remote_company_id"`
+				}{}
+				if err := json.Unmarshal(value, &aux); err != nil {
+					return nil, err
+				}
+				count.Count, count.RemoteCompanyID = aux.Count, aux.RemoteCompanyID
+			} else if err := json.Unmarshal(value, &count.Count); err != nil {
+				return nil, err
+			}
+			counts = append(counts, count)
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,88 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// CountRepository defines the interface for working with Counts through the API.
+type CountRepository interface {
+	app(context.Context) (AppCount, error)
+	list(context.Context, countParams) ([]NamedCount, error)
+	conversations(context.Context) (ConversationCount, error)
+	conversationsByAdmin(context.Context) ([]AdminConversationCount, error)
+}
+
+// CountAPI implements CountRepository
+type CountAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+func (api CountAPI) app(ctx context.Context) (AppCount, error) {
+	ctx = interfaces.WithOperation(ctx, "counts", "app")
+	appCount := AppCount{}
+	data, err := api.httpClient.Get(ctx, "/counts", nil)
+	if err != nil {
+		return appCount, err
+	}
+	err = json.Unmarshal(data, &appCount)
+	return appCount, err
+}
+
+// list fetches counts nested by type then count, such as {"user": {"segment": [{"Active": 5}]}}.
+func (api CountAPI) list(ctx context.Context, params countParams) ([]NamedCount, error) {
+	ctx = interfaces.WithOperation(ctx, "counts", countAction(params))
+	data, err := api.httpClient.Get(ctx, "/counts", params)
+	if err != nil {
+		return nil, err
+	}
+	response := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	counts := map[string]json.RawMessage{}
+	if typeCounts, ok := response[params.Type]; ok {
+		if err := json.Unmarshal(typeCounts, &counts); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := counts[params.Count]; !ok {
+		return []NamedCount{}, nil
+	}
+	return unmarshalNamedCounts(counts[params.Count])
+}
+
+func (api CountAPI) conversations(ctx context.Context) (ConversationCount, error) {
+	ctx = interfaces.WithOperation(ctx, "counts", "conversation")
+	response := struct {
+		Conversation ConversationCount `json:"conversation"`
+	}{}
+	data, err := api.httpClient.Get(ctx, "/counts", countParams{Type: "conversation"})
+	if err != nil {
+		return response.Conversation, err
+	}
+	err = json.Unmarshal(data, &response)
+	return response.Conversation, err
+}
+
+func (api CountAPI) conversationsByAdmin(ctx context.Context) ([]AdminConversationCount, error) {
+	ctx = interfaces.WithOperation(ctx, "counts", "conversation_by_admin")
+	response := struct {
+		Conversation struct {
+			Admin []AdminConversationCount `json:"admin"`
+		} `json:"conversation"`
+	}{}
+	data, err := api.httpClient.Get(ctx, "/counts", countParams{Type: "conversation", Count: "admin"})
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &response)
+	return response.Conversation.Admin, err
+}
+
+// countAction names the counts listed, such as "user_by_segment".
+func countAction(params countParams) string {
+	return params.Type + "_by_" + params.Count
+}
@@ -0,0 +1,93 @@
+package intercom
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+func TestCountAPIApp(t *testing.T) {
+	http := TestCountHTTPClient{t: t, fixtureFilename: "fixtures/counts.json"}
+	api := CountAPI{httpClient: &http}
+	count, err := api.app(context.Background())
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if count != (AppCount{Company: 8, Lead: 12, Segment: 36, Tag: 2, User: 1067}) {
+		t.Errorf("Count was %+v", count)
+	}
+	if http.lastQueryParams != nil {
+		t.Errorf("Params were %+v, expected none", http.lastQueryParams)
+	}
+}
+
+func TestCountAPIList(t *testing.T) {
+	http := TestCountHTTPClient{t: t, fixtureFilename: "fixtures/counts_user_segment.json"}
+	api := CountAPI{httpClient: &http}
+	counts, err := api.list(context.Background(), countParams{Type: "user", Count: "segment"})
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if fmt.Sprint(counts) != "[{Active 1 } {New 0 } {Slipping Away 0 }]" {
+		t.Errorf("Counts were %v", counts)
+	}
+	if params := http.lastQueryParams.(countParams); params.Type != "user" || params.Count != "segment" {
+		t.Errorf("Params were %+v", params)
+	}
+	counts, err = api.list(context.Background(), countParams{Type: "company", Count: "tag"})
+	if err != nil || len(counts) != 0 {
+		t.Errorf("Counts were %v, error %v, expected none", counts, err)
+	}
+}
+
+func TestCountAPIListCompanyUsers(t *testing.T) {
+	http := TestCountHTTPClient{t: t, fixtureFilename: "fixtures/counts_company_user.json"}
+	api := CountAPI{httpClient: &http}
+	counts, err := api.list(context.Background(), countParams{Type: "company", Count: "user"})
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if fmt.Sprint(counts) != "[{Acme 7 6} {Globex 0 12}]" {
+		t.Errorf("Counts were %v", counts)
+	}
+}
+
+func TestCountAPIConversations(t *testing.T) {
+	http := TestCountHTTPClient{t: t, fixtureFilename: "fixtures/counts_conversation.json"}
+	api := CountAPI{httpClient: &http}
+	count, err := api.conversations(context.Background())
+	if err != nil || count != (ConversationCount{Open: 16, Closed: 15, Assigned: 1, Unassigned: 15}) {
+		t.Errorf("Count was %+v, error %v", count, err)
+	}
+}
+
+func TestCountAPIConversationsByAdmin(t *testing.T) {
+	http := TestCountHTTPClient{t: t, fixtureFilename: "fixtures/counts_conversation_admin.json"}
+	api := CountAPI{httpClient: &http}
+	counts, err := api.conversationsByAdmin(context.Background())
+	if err != nil || len(counts) != 2 {
+		t.Fatalf("Counts were %+v, error %v", counts, err)
+	}
+	if counts[0].Name != "Jamie" || counts[0].Open != 7 || counts[1].ID != "81" || counts[1].Closed != 5 {
+		t.Errorf("Counts were %+v", counts)
+	}
+	if params := http.lastQueryParams.(countParams); params.Type != "conversation" || params.Count != "admin" {
+		t.Errorf("Params were %+v", params)
+	}
+}
+
+type TestCountHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	fixtureFilename string
+	lastQueryParams interface{}
+}
+
+func (t *TestCountHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	if uri != "/counts" {
+		t.t.Errorf("URI was %s, expected /counts", uri)
+	}
+	t.lastQueryParams = queryParams
+	return ioutil.ReadFile(t.fixtureFilename)
+}
@@ -0,0 +1,63 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestCountServiceParams(t *testing.T) {
+	api := &TestCountAPI{}
+	service := CountService{Repository: api}
+	ctx := context.Background()
+	service.UsersBySegment(ctx)
+	service.UsersByTag(ctx)
+	service.CompaniesBySegment(ctx)
+	service.CompaniesByTag(ctx)
+	service.UsersByCompany(ctx)
+	expected := []countParams{{"user", "segment"}, {"user", "tag"}, {"company", "segment"}, {"company", "tag"}, {"company", "user"}}
+	if len(api.params) != len(expected) {
+		t.Fatalf("Params were %v", api.params)
+	}
+	for i := range expected {
+		if api.params[i] != expected[i] {
+			t.Errorf("Params were %v, expected %v", api.params[i], expected[i])
+		}
+	}
+}
+
+func TestCountServiceApp(t *testing.T) {
+	count, _ := (&CountService{Repository: &TestCountAPI{}}).App(context.Background())
+	if count.User != 10 {
+		t.Errorf("Count was %+v", count)
+	}
+}
+
+func TestNamedCountJSON(t *testing.T) {
+	b, _ := json.Marshal([]NamedCount{{Name: "Acme", Count: 7, RemoteCompanyID: "6"}, {Name: "VIP", Count: 1}})
+	expected := `[{"name":"Acme","count":7,"remote_company_id":"6"},{"name":"VIP","count":1}]`
+	if string(b) != expected {
+		t.Errorf("JSON was %s, expected %s", b, expected)
+	}
+}
+
+type TestCountAPI struct {
+	params []countParams
+}
+
+func (t *TestCountAPI) app(ctx context.Context) (AppCount, error) {
+	return AppCount{User: 10}, nil
+}
+
+func (t *TestCountAPI) list(ctx context.Context, params countParams) ([]NamedCount, error) {
+	t.params = append(t.params, params)
+	return []NamedCount{{Name: "Active", Count: 1}}, nil
+}
+
+func (t *TestCountAPI) conversations(ctx context.Context) (ConversationCount, error) {
+	return ConversationCount{Open: 1}, nil
+}
+
+func (t *TestCountAPI) conversationsByAdmin(ctx context.Context) ([]AdminConversationCount, error) {
+	return []AdminConversationCount{{ID: "1", Open: 1}}, nil
+}
@@ -0,0 +1,233 @@
+package intercom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// maxCustomAttributeNameLength is the longest name Intercom accepts for a custom attribute.
+const maxCustomAttributeNameLength = 190
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// MarshalCustomAttributes maps the fields of a struct tagged `intercom:"name"` to custom attributes.
+// Fields may be strings, bools, numbers, time.Time (sent as a Unix timestamp) or pointers to them.
+// A nil pointer or zero time clears the attribute, unless the tag has the omitempty option, such as
+// `intercom:"plan,omitempty"`, in which case zero values are left out. Untagged fields, and those
+// tagged "-", are skipped, except embedded structs whose fields are mapped in turn.
+func MarshalCustomAttributes(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("[intercom] cannot marshal custom attributes from %T, expected a struct", v)
+	}
+	attributes := map[string]interface{}{}
+	err := eachCustomAttributeField(rv, func(name string, omitEmpty bool, field reflect.Value) error {
+		value, err := customAttributeValue(field)
+		if err != nil {
+			return CustomAttributeError{Name: name, Reason: err.Error()}
+		}
+		if omitEmpty && (value == nil || field.IsZero()) {
+			return nil
+		}
+		attributes[name] = value
+		return nil
+	})
+	return attributes, err
+}
+
+// UnmarshalCustomAttributes sets the fields of the struct pointed to by v from custom attributes,
+// such as those of a fetched User, using the same tags as MarshalCustomAttributes. Fields for
+// missing attributes are left unchanged, and those for null attributes are set to their zero value.
+func UnmarshalCustomAttributes(attributes map[string]interface{}, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("[intercom] cannot unmarshal custom attributes into %T, expected a pointer to a struct", v)
+	}
+	return eachCustomAttributeField(rv.Elem(), func(name string, omitEmpty bool, field reflect.Value) error {
+		value, ok := attributes[name]
+		if !ok {
+			return nil
+		}
+		if err := setCustomAttributeValue(field, value); err != nil {
+			return CustomAttributeError{Name: name, Reason: err.Error()}
+		}
+		return nil
+	})
+}
+
+// EncodeCustomAttributes marshals a tagged struct into the User's CustomAttributes, keeping any others.
+func (u *User) EncodeCustomAttributes(v interface{}) error {
+	return encodeCustomAttributes(&u.CustomAttributes, v)
+}
+
+// DecodeCustomAttributes unmarshals the User's CustomAttributes into a tagged struct.
+func (u User) DecodeCustomAttributes(v interface{}) error {
+	return UnmarshalCustomAttributes(u.CustomAttributes, v)
+}
+
+// EncodeCustomAttributes marshals a tagged struct into the Company's CustomAttributes, keeping any others.
+func (c *Company) EncodeCustomAttributes(v interface{}) error {
+	return encodeCustomAttributes(&c.CustomAttributes, v)
+}
+
+// DecodeCustomAttributes unmarshals the Company's CustomAttributes into a tagged struct.
+func (c Company) DecodeCustomAttributes(v interface{}) error {
+	return UnmarshalCustomAttributes(c.CustomAttributes, v)
+}
+
+func encodeCustomAttributes(attributes *map[string]interface{}, v interface{}) error {
+	encoded, err := MarshalCustomAttributes(v)
+	if err != nil {
+		return err
+	}
+	if *attributes == nil {
+		*attributes = map[string]interface{}{}
+	}
+	for name, value := range encoded {
+		(*attributes)[name] = value
+	}
+	return nil
+}
+
+// eachCustomAttributeField calls fn with each tagged field of a struct, checking the attribute names.
+func eachCustomAttributeField(rv reflect.Value, fn func(name string, omitEmpty bool, field reflect.Value) error) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		structField := rt.Field(i)
+		tag, tagged := structField.Tag.Lookup("intercom")
+		if !tagged && structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			if err := eachCustomAttributeField(rv.Field(i), fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !tagged || tag == "-" || structField.PkgPath != "" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if err := checkCustomAttributeName(name); err != nil {
+			return CustomAttributeError{Name: name, Reason: err.Error()}
+		}
+		if err := fn(name, options == "omitempty", rv.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkCustomAttributeName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("must have a name")
+	case len(name) > maxCustomAttributeNameLength:
+		return fmt.Errorf("name is longer than %d characters", maxCustomAttributeNameLength)
+	case strings.ContainsAny(name, ".$"):
+		return errors.New("name must not contain . or $")
+	}
+	return nil
+}
+
+// customAttributeValue converts a field to the value sent to the API.
+func customAttributeValue(field reflect.Value) (interface{}, error) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, nil
+		}
+		field = field.Elem()
+	}
+	if field.Type() == timeType {
+		t := field.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t.Unix(), nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return field.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), nil
+	}
+	return nil, fmt.Errorf("cannot be a %s", field.Type())
+}
+
+// setCustomAttributeValue sets a field from a value received from the API, where numbers are usually float64.
+func setCustomAttributeValue(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setCustomAttributeValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if field.Type() == timeType {
+		seconds, ok := customAttributeInt(rv)
+		if !ok {
+			return fmt.Errorf("cannot set a time from %T", value)
+		}
+		field.Set(reflect.ValueOf(time.Unix(seconds, 0).UTC()))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		if rv.Kind() == reflect.String {
+			field.SetString(rv.String())
+			return nil
+		}
+	case reflect.Bool:
+		if rv.Kind() == reflect.Bool {
+			field.SetBool(rv.Bool())
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := customAttributeInt(rv); ok && !field.OverflowInt(n) {
+			field.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := customAttributeInt(rv); ok && n >= 0 && !field.OverflowUint(uint64(n)) {
+			field.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(rv.Float())
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetFloat(float64(rv.Int()))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot set a %s from %v", field.Type(), value)
+}
+
+// customAttributeInt gets a whole number from an integer, or a float without a fractional part.
+func customAttributeInt(rv reflect.Value) (int64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), rv.Uint() <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return int64(f), f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+	}
+	return 0, false
+}
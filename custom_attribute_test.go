@@ -0,0 +1,126 @@
+package intercom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAccount struct {
+	Plan      string     `intercom:"plan"`
+	Seats     int        `intercom:"seats"`
+	Score     float64    `intercom:"score,omitempty"`
+	Paid      bool       `intercom:"paid"`
+	RenewsAt  time.Time  `intercom:"renews_at"`
+	TrialEnds *time.Time `intercom:"trial_ends_at"`
+	Referrer  *string    `intercom:"referrer,omitempty"`
+	Internal  string     `intercom:"-"`
+	Untagged  string
+	testAccountOwner
+}
+
+type testAccountOwner struct {
+	Owner string `intercom:"owner"`
+}
+
+func TestMarshalCustomAttributes(t *testing.T) {
+	renewsAt := time.Unix(1500000000, 0)
+	account := testAccount{Plan: "pro", Seats: 5, Paid: true, RenewsAt: renewsAt, Internal: "x", Untagged: "y", testAccountOwner: testAccountOwner{Owner: "jo"}}
+	attributes, err := MarshalCustomAttributes(&account)
+	if err != nil {
+		t.Fatalf("Error marshaling: %v", err)
+	}
+	expected := map[string]interface{}{"plan": "pro", "seats": int64(5), "paid": true, "renews_at": int64(1500000000), "trial_ends_at": nil, "owner": "jo"}
+	if len(attributes) != len(expected) {
+		t.Errorf("Attributes were %v, expected %v", attributes, expected)
+	}
+	for name, value := range expected {
+		if actual, ok := attributes[name]; !ok || actual != value {
+			t.Errorf("Attribute %s was %v (%T), expected %v (%T)", name, actual, actual, value, value)
+		}
+	}
+}
+
+func TestUnmarshalCustomAttributes(t *testing.T) {
+	account := testAccount{Score: 1.5, Referrer: new(string)}
+	attributes := map[string]interface{}{"plan": "free", "seats": 3.0, "paid": false, "renews_at": 1500000000.0, "trial_ends_at": 1400000000.0, "referrer": nil, "owner": "jo"}
+	if err := UnmarshalCustomAttributes(attributes, &account); err != nil {
+		t.Fatalf("Error unmarshaling: %v", err)
+	}
+	if account.Plan != "free" || account.Seats != 3 || account.Score != 1.5 || account.Owner != "jo" {
+		t.Errorf("Account was %+v", account)
+	}
+	if !account.RenewsAt.Equal(time.Unix(1500000000, 0)) || account.TrialEnds == nil || account.TrialEnds.Unix() != 1400000000 {
+		t.Errorf("Times were %v and %v", account.RenewsAt, account.TrialEnds)
+	}
+	if account.Referrer != nil {
+		t.Errorf("Referrer was %v, expected nil", account.Referrer)
+	}
+}
+
+func TestUnmarshalCustomAttributesTypeMismatch(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"seats": "five"},
+		{"seats": 5.5},
+		{"paid": "yes"},
+		{"renews_at": "2017-07-14"},
+	}
+	for _, attributes := range tests {
+		err := UnmarshalCustomAttributes(attributes, &testAccount{})
+		if !errors.As(err, &CustomAttributeError{}) {
+			t.Errorf("Unmarshaling %v returned %v, expected a CustomAttributeError", attributes, err)
+		}
+	}
+	if err := UnmarshalCustomAttributes(map[string]interface{}{}, testAccount{}); err == nil {
+		t.Errorf("Expected an error unmarshaling into a non-pointer")
+	}
+}
+
+func TestMarshalCustomAttributesNames(t *testing.T) {
+	tests := []interface{}{
+		struct {
+			Name string `intercom:"a.b"`
+		}{},
+		struct {
+			Name string `intercom:"$a"`
+		}{},
+		struct {
+			Name string `intercom:",omitempty"`
+		}{},
+		struct {
+			Tags []string `intercom:"tags"`
+		}{},
+	}
+	for _, v := range tests {
+		if _, err := MarshalCustomAttributes(v); err == nil {
+			t.Errorf("Expected an error marshaling %T", v)
+		}
+	}
+	long := struct {
+		Name string `intercom:"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"`
+	}{}
+	if _, err := MarshalCustomAttributes(long); err == nil || !strings.Contains(err.Error(), "longer than 190") {
+		t.Errorf("Expected an error for a long name, was %v", err)
+	}
+}
+
+func TestUserEncodeCustomAttributes(t *testing.T) {
+	user := User{UserID: "27", CustomAttributes: map[string]interface{}{"existing": 1}}
+	if err := user.EncodeCustomAttributes(testAccount{Plan: "pro"}); err != nil {
+		t.Fatalf("Error encoding: %v", err)
+	}
+	if user.CustomAttributes["plan"] != "pro" || user.CustomAttributes["existing"] != 1 {
+		t.Errorf("CustomAttributes were %v", user.CustomAttributes)
+	}
+	account := testAccount{}
+	user.DecodeCustomAttributes(&account)
+	if account.Plan != "pro" {
+		t.Errorf("Plan was %s, expected pro", account.Plan)
+	}
+	company := Company{}
+	company.EncodeCustomAttributes(&testAccount{Seats: 2})
+	if company.CustomAttributes["seats"] != int64(2) {
+		t.Errorf("CustomAttributes were %v", company.CustomAttributes)
+	}
+}
@@ -0,0 +1,267 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// DataAttributeService handles interactions with the API through a DataAttributeRepository.
+// Requires API version 2.0 or newer. It caches the schema of custom attributes for each model, used by ValidateCustomAttributes.
+// Copies of the service share the cache. A service made without NewClient does not cache.
+type DataAttributeService struct {
+	Repository DataAttributeRepository
+
+	schemas *schemaCache
+}
+
+// schemaCache holds the custom DataAttributes of each model, by name.
+// Clearing it moves it to a new generation, so a schema listed before then is not stored.
+type schemaCache struct {
+	mu         sync.Mutex
+	generation int
+	schemas    map[DataAttributeModel]map[string]DataAttribute
+}
+
+func (c *schemaCache) get(model DataAttributeModel) (map[string]DataAttribute, int, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	schema, ok := c.schemas[model]
+	return schema, c.generation, ok
+}
+
+func (c *schemaCache) put(generation int, model DataAttributeModel, schema map[string]DataAttribute) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if c.schemas == nil {
+		c.schemas = map[DataAttributeModel]map[string]DataAttribute{}
+	}
+	c.schemas[model] = schema
+}
+
+func (c *schemaCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.schemas = nil
+}
+
+// DataAttributeModel is the model a DataAttribute belongs to.
+type DataAttributeModel int
+
+const (
+	MODEL_CONTACT DataAttributeModel = iota
+	MODEL_COMPANY
+	MODEL_CONVERSATION
+)
+
+var dataAttributeModels = [...]string{
+	"contact",
+	"company",
+	"conversation",
+}
+
+func (model DataAttributeModel) String() string {
+	return dataAttributeModels[model]
+}
+
+// DataType is the type of value a DataAttribute holds.
+type DataType int
+
+const (
+	DATA_TYPE_STRING DataType = iota
+	DATA_TYPE_INTEGER
+	DATA_TYPE_FLOAT
+	DATA_TYPE_BOOLEAN
+	DATA_TYPE_DATE
+	DATA_TYPE_DATETIME
+)
+
+var dataTypes = [...]string{
+	"string",
+	"integer",
+	"float",
+	"boolean",
+	"date",
+	"datetime",
+}
+
+func (dataType DataType) String() string {
+	return dataTypes[dataType]
+}
+
+// DataAttributeList holds a list of DataAttributes.
+type DataAttributeList struct {
+	DataAttributes []DataAttribute `json:"data"`
+}
+
+// DataAttribute describes an attribute of Contacts, Companies or Conversations, such as a custom attribute and its type.
+type DataAttribute struct {
+	ID                json.Number `json:"id,omitempty"`
+	Model             string      `json:"model"`
+	Name              string      `json:"name"`
+	FullName          string      `json:"full_name,omitempty"`
+	Label             string      `json:"label,omitempty"`
+	Description       string      `json:"description,omitempty"`
+	DataType          string      `json:"data_type"`
+	Options           []string    `json:"options,omitempty"`
+	APIWritable       bool        `json:"api_writable"`
+	MessengerWritable bool        `json:"messenger_writable"`
+	Custom            bool        `json:"custom"`
+	Archived          bool        `json:"archived"`
+	CreatedAt         int64       `json:"created_at,omitempty"`
+	UpdatedAt         int64       `json:"updated_at,omitempty"`
+}
+
+// A CustomAttributeError describes a custom attribute that does not match the schema of its model,
+// or a struct field that cannot be mapped to one.
+type CustomAttributeError struct {
+	Model  string
+	Name   string
+	Reason string
+}
+
+func (e CustomAttributeError) Error() string {
+	if e.Model == "" {
+		return fmt.Sprintf("[intercom] custom attribute %s %s", e.Name, e.Reason)
+	}
+	return fmt.Sprintf("[intercom] %s custom attribute %s %s", e.Model, e.Name, e.Reason)
+}
+
+// List the DataAttributes of a model, optionally including archived ones.
+func (d *DataAttributeService) List(ctx context.Context, model DataAttributeModel, includeArchived bool) (DataAttributeList, error) {
+	return d.Repository.list(ctx, dataAttributeListParams{Model: model.String(), IncludeArchived: includeArchived})
+}
+
+// Create a custom DataAttribute, which must have a Name, Model and DataType.
+func (d *DataAttributeService) Create(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	if attribute.Name == "" || attribute.Model == "" || attribute.DataType == "" {
+		return DataAttribute{}, errors.New("Missing DataAttribute Name, Model or DataType")
+	}
+	created, err := d.Repository.create(ctx, attribute)
+	if err == nil {
+		d.ClearSchemas()
+	}
+	return created, err
+}
+
+// Update the description, options, messenger writability or archived state of a DataAttribute, identified by its ID.
+func (d *DataAttributeService) Update(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	if attribute.ID == "" {
+		return DataAttribute{}, errors.New("Missing DataAttribute ID")
+	}
+	updated, err := d.Repository.update(ctx, attribute)
+	if err == nil {
+		d.ClearSchemas()
+	}
+	return updated, err
+}
+
+// ClearSchemas clears the cached schemas, so they are listed again on next use.
+func (d *DataAttributeService) ClearSchemas() {
+	d.schemas.clear()
+}
+
+// ValidateCustomAttributes checks custom attributes against the schema of a model, returning a
+// CustomAttributeError for the first that is unknown, archived, not writable or of the wrong type.
+// The schema is listed on first use and cached. A nil value, which clears an attribute, is always valid.
+func (d *DataAttributeService) ValidateCustomAttributes(ctx context.Context, model DataAttributeModel, attributes map[string]interface{}) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+	schema, err := d.schema(ctx, model)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if reason := checkCustomAttribute(schema, name, attributes[name]); reason != "" {
+			return CustomAttributeError{Model: model.String(), Name: name, Reason: reason}
+		}
+	}
+	return nil
+}
+
+func (d *DataAttributeService) schema(ctx context.Context, model DataAttributeModel) (map[string]DataAttribute, error) {
+	schema, generation, ok := d.schemas.get(model)
+	if ok {
+		return schema, nil
+	}
+	attributeList, err := d.Repository.list(ctx, dataAttributeListParams{Model: model.String(), IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	schema = map[string]DataAttribute{}
+	for _, attribute := range attributeList.DataAttributes {
+		if attribute.Custom {
+			schema[attribute.Name] = attribute
+		}
+	}
+	d.schemas.put(generation, model, schema)
+	return schema, nil
+}
+
+// checkCustomAttribute gives the reason a value does not match the schema, or "" if it does.
+func checkCustomAttribute(schema map[string]DataAttribute, name string, value interface{}) string {
+	attribute, ok := schema[name]
+	switch {
+	case !ok:
+		return "is not defined"
+	case attribute.Archived:
+		return "is archived"
+	case !attribute.APIWritable:
+		return "is not writable through the API"
+	case value != nil && !matchesDataType(attribute.DataType, value):
+		return fmt.Sprintf("must be a %s, was %T", attribute.DataType, value)
+	}
+	return ""
+}
+
+func matchesDataType(dataType string, value interface{}) bool {
+	integer := dataType == DATA_TYPE_INTEGER.String() || dataType == DATA_TYPE_DATE.String() || dataType == DATA_TYPE_DATETIME.String()
+	if number, ok := value.(json.Number); ok {
+		if _, err := number.Int64(); err == nil {
+			return integer || dataType == DATA_TYPE_FLOAT.String()
+		}
+		return dataType == DATA_TYPE_FLOAT.String()
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return dataType == DATA_TYPE_STRING.String()
+	case reflect.Bool:
+		return dataType == DATA_TYPE_BOOLEAN.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return integer || dataType == DATA_TYPE_FLOAT.String()
+	case reflect.Float32, reflect.Float64:
+		// Whole floats, such as decoded from JSON, are valid integers.
+		return dataType == DATA_TYPE_FLOAT.String() || (integer && v.Float() == math.Trunc(v.Float()))
+	}
+	return false
+}
+
+func (d DataAttribute) String() string {
+	return fmt.Sprintf("[intercom] %s data_attribute { id: %s name: %s, data_type: %s }", d.Model, d.ID, d.Name, d.DataType)
+}
@@ -0,0 +1,95 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// DataAttributeRepository defines the interface for working with DataAttributes through the API.
+type DataAttributeRepository interface {
+	list(context.Context, dataAttributeListParams) (DataAttributeList, error)
+	create(context.Context, *DataAttribute) (DataAttribute, error)
+	update(context.Context, *DataAttribute) (DataAttribute, error)
+}
+
+// DataAttributeAPI implements DataAttributeRepository
+type DataAttributeAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type dataAttributeListParams struct {
+	Model           string `url:"model,omitempty"`
+	IncludeArchived bool   `url:"include_archived,omitempty"`
+}
+
+type requestDataAttribute struct {
+	Name              string                       `json:"name,omitempty"`
+	Model             string                       `json:"model,omitempty"`
+	DataType          string                       `json:"data_type,omitempty"`
+	Description       string                       `json:"description,omitempty"`
+	Options           []requestDataAttributeOption `json:"options,omitempty"`
+	MessengerWritable bool                         `json:"messenger_writable"`
+	Archived          *bool                        `json:"archived,omitempty"`
+}
+
+type requestDataAttributeOption struct {
+	Value string `json:"value"`
+}
+
+func (api DataAttributeAPI) list(ctx context.Context, params dataAttributeListParams) (DataAttributeList, error) {
+	ctx = interfaces.WithOperation(ctx, "data_attributes", "list")
+	attributeList := DataAttributeList{}
+	if err := requireVersion(api.httpClient, "2.0", "GET", "/data_attributes"); err != nil {
+		return attributeList, err
+	}
+	data, err := api.httpClient.Get(ctx, "/data_attributes", params)
+	if err != nil {
+		return attributeList, err
+	}
+	err = json.Unmarshal(data, &attributeList)
+	return attributeList, err
+}
+
+func (api DataAttributeAPI) create(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	ctx = interfaces.WithOperation(ctx, "data_attributes", "create")
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/data_attributes"); err != nil {
+		return DataAttribute{}, err
+	}
+	request := api.buildRequestDataAttribute(attribute)
+	request.Name, request.Model, request.DataType = attribute.Name, attribute.Model, attribute.DataType
+	return unmarshalToDataAttribute(api.httpClient.Post(ctx, "/data_attributes", request))
+}
+
+func (api DataAttributeAPI) update(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	ctx = interfaces.WithOperation(ctx, "data_attributes", "update")
+	path := fmt.Sprintf("/data_attributes/%s", attribute.ID)
+	if err := requireVersion(api.httpClient, "2.0", "PUT", path); err != nil {
+		return DataAttribute{}, err
+	}
+	request := api.buildRequestDataAttribute(attribute)
+	request.Archived = &attribute.Archived
+	return unmarshalToDataAttribute(interfaces.Put(ctx, api.httpClient, path, request))
+}
+
+func unmarshalToDataAttribute(data []byte, err error) (DataAttribute, error) {
+	savedAttribute := DataAttribute{}
+	if err != nil {
+		return savedAttribute, err
+	}
+	err = json.Unmarshal(data, &savedAttribute)
+	return savedAttribute, err
+}
+
+func (api DataAttributeAPI) buildRequestDataAttribute(attribute *DataAttribute) requestDataAttribute {
+	request := requestDataAttribute{
+		Description:       attribute.Description,
+		MessengerWritable: attribute.MessengerWritable,
+	}
+	for _, option := range attribute.Options {
+		request.Options = append(request.Options, requestDataAttributeOption{Value: option})
+	}
+	return request
+}
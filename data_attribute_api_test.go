@@ -0,0 +1,81 @@
+package intercom
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDataAttributeAPIList(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attributes.json", expectedMethod: "GET", expectedURI: "/data_attributes"}
+	api := DataAttributeAPI{httpClient: &http}
+	attributeList, err := api.list(context.Background(), dataAttributeListParams{Model: "contact"})
+	if err != nil {
+		t.Fatalf("Error parsing fixture %s", err)
+	}
+	if params := http.lastBody.(dataAttributeListParams); params.Model != "contact" {
+		t.Errorf("Model was %s, expected contact", params.Model)
+	}
+	attribute := attributeList.DataAttributes[1]
+	if attribute.ID != "12878" || attribute.Name != "plan" || attribute.DataType != "string" || !attribute.Custom {
+		t.Errorf("DataAttribute was %+v", attribute)
+	}
+	if len(attribute.Options) != 2 || attribute.Options[1] != "pro" {
+		t.Errorf("Options were %v", attribute.Options)
+	}
+}
+
+func TestDataAttributeAPICreate(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attribute.json", expectedMethod: "POST", expectedURI: "/data_attributes"}
+	api := DataAttributeAPI{httpClient: &http}
+	attribute, _ := api.create(context.Background(), &DataAttribute{Name: "plan", Model: "contact", DataType: "string", Options: []string{"free", "pro"}})
+	body := http.lastBody.(requestDataAttribute)
+	if body.Name != "plan" || body.Model != "contact" || body.DataType != "string" || body.Archived != nil {
+		t.Errorf("Body was %+v", body)
+	}
+	if len(body.Options) != 2 || body.Options[0].Value != "free" {
+		t.Errorf("Options were %v", body.Options)
+	}
+	if attribute.ID != "12878" {
+		t.Errorf("ID was %s, expected 12878", attribute.ID)
+	}
+}
+
+func TestDataAttributeAPIUpdate(t *testing.T) {
+	http := TestDataAttributeHTTPClient{t: t, fixtureFilename: "fixtures/data_attribute.json", expectedMethod: "PUT", expectedURI: "/data_attributes/12878"}
+	api := DataAttributeAPI{httpClient: &http}
+	api.update(context.Background(), &DataAttribute{ID: "12878", Name: "plan", Description: "Billing plan", Archived: true})
+	body := http.lastBody.(requestDataAttribute)
+	if body.Name != "" || body.Description != "Billing plan" || body.Archived == nil || !*body.Archived {
+		t.Errorf("Body was %+v", body)
+	}
+}
+
+type TestDataAttributeHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	fixtureFilename string
+	expectedMethod  string
+	expectedURI     string
+	lastBody        interface{}
+}
+
+func (t *TestDataAttributeHTTPClient) request(method, uri string, body interface{}) ([]byte, error) {
+	if t.expectedMethod != method || t.expectedURI != uri {
+		t.t.Errorf("Called %s %s, expected %s %s", method, uri, t.expectedMethod, t.expectedURI)
+	}
+	t.lastBody = body
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestDataAttributeHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	return t.request("GET", uri, queryParams)
+}
+
+func (t *TestDataAttributeHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return t.request("POST", uri, body)
+}
+
+func (t *TestDataAttributeHTTPClient) Put(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return t.request("PUT", uri, body)
+}
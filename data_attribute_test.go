@@ -0,0 +1,166 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestDataAttributeList(t *testing.T) {
+	api := &TestDataAttributeAPI{t: t}
+	attributeService := DataAttributeService{Repository: api}
+	attributeList, _ := attributeService.List(context.Background(), MODEL_COMPANY, true)
+	if len(attributeList.DataAttributes) != 8 {
+		t.Errorf("DataAttributes not listed")
+	}
+	if api.lastParams.Model != "company" || !api.lastParams.IncludeArchived {
+		t.Errorf("Params were %+v", api.lastParams)
+	}
+}
+
+func TestDataAttributeCreateRequiresType(t *testing.T) {
+	attributeService := DataAttributeService{Repository: &TestDataAttributeAPI{t: t}}
+	if _, err := attributeService.Create(context.Background(), &DataAttribute{Name: "plan", Model: "contact"}); err == nil {
+		t.Errorf("Expected an error creating a DataAttribute without a DataType")
+	}
+	if _, err := attributeService.Update(context.Background(), &DataAttribute{Name: "plan"}); err == nil {
+		t.Errorf("Expected an error updating a DataAttribute without an ID")
+	}
+}
+
+func TestValidateCustomAttributes(t *testing.T) {
+	tests := []struct {
+		attributes map[string]interface{}
+		name       string
+	}{
+		{map[string]interface{}{"plan": "pro", "seats": 5, "score": 2.5, "paid": true, "renews_at": int64(1500000000)}, ""},
+		{map[string]interface{}{"seats": 5.0, "score": json.Number("3"), "plan": nil}, ""},
+		{map[string]interface{}{"plna": "pro"}, "plna"},
+		{map[string]interface{}{"plan": "pro", "seats": "5"}, "seats"},
+		{map[string]interface{}{"seats": 5.5}, "seats"},
+		{map[string]interface{}{"paid": "yes"}, "paid"},
+		{map[string]interface{}{"legacy": "a"}, "legacy"},
+		{map[string]interface{}{"computed": 1}, "computed"},
+	}
+	attributeService := DataAttributeService{Repository: &TestDataAttributeAPI{t: t}}
+	for _, test := range tests {
+		err := attributeService.ValidateCustomAttributes(context.Background(), MODEL_CONTACT, test.attributes)
+		attributeErr := CustomAttributeError{}
+		switch {
+		case test.name == "" && err != nil:
+			t.Errorf("Validating %v failed: %v", test.attributes, err)
+		case test.name != "" && !errors.As(err, &attributeErr):
+			t.Errorf("Validating %v returned %v, expected a CustomAttributeError", test.attributes, err)
+		case test.name != "" && attributeErr.Name != test.name:
+			t.Errorf("Validating %v failed for %s, expected %s", test.attributes, attributeErr.Name, test.name)
+		}
+	}
+}
+
+func TestValidateCustomAttributesCachesSchema(t *testing.T) {
+	api := &TestDataAttributeAPI{t: t}
+	attributeService := DataAttributeService{Repository: api, schemas: &schemaCache{}}
+	ctx := context.Background()
+	attributeService.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	attributeService.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"seats": 1})
+	if api.lists != 1 {
+		t.Errorf("Schema listed %d times, expected 1", api.lists)
+	}
+	attributeService.Create(ctx, &DataAttribute{Name: "region", Model: "contact", DataType: "string"})
+	attributeService.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	if api.lists != 2 {
+		t.Errorf("Schema listed %d times after create, expected 2", api.lists)
+	}
+}
+
+func TestSchemaListedDuringCreateNotCached(t *testing.T) {
+	api := &TestCreatingDataAttributeAPI{TestDataAttributeAPI: &TestDataAttributeAPI{t: t}}
+	attributeService := &DataAttributeService{Repository: api, schemas: &schemaCache{}}
+	api.service = attributeService
+	ctx := context.Background()
+	attributeService.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	attributeService.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	if api.lists != 2 {
+		t.Errorf("Schema listed %d times, expected 2", api.lists)
+	}
+}
+
+func TestSchemaCacheKeptAcrossOptions(t *testing.T) {
+	api := &TestDataAttributeAPI{t: t}
+	ic := NewClient("a", "b")
+	ic.DataAttributes.Repository = api
+	ctx := context.Background()
+	ic.DataAttributes.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	ic.Option(ValidateCustomAttributes(true))
+	ic.DataAttributes.Repository = api
+	ic.Users.DataAttributes.ValidateCustomAttributes(ctx, MODEL_CONTACT, map[string]interface{}{"plan": "pro"})
+	if api.lists != 1 {
+		t.Errorf("Schema listed %d times, expected 1", api.lists)
+	}
+}
+
+func TestValidateCustomAttributesRequiresVersion(t *testing.T) {
+	ic := NewClient("a", "b")
+	ic.Option(APIVersion("1.4"), ValidateCustomAttributes(true))
+	_, err := ic.Users.Save(context.Background(), &User{UserID: "123", CustomAttributes: map[string]interface{}{"plan": "pro"}})
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Error was %v, expected a VersionError", err)
+	}
+}
+
+func TestSaveValidatesCustomAttributes(t *testing.T) {
+	attributeService := &DataAttributeService{Repository: &TestDataAttributeAPI{t: t}}
+	userService := UserService{Repository: TestUserAPI{t: t}, DataAttributes: attributeService}
+	if _, err := userService.Save(context.Background(), &User{UserID: "123", CustomAttributes: map[string]interface{}{"seats": "many"}}); err == nil {
+		t.Errorf("Expected an error saving a User with an invalid custom attribute")
+	}
+	companyService := CompanyService{Repository: TestCompanyAPI{t: t}, DataAttributes: attributeService}
+	if _, err := companyService.Save(context.Background(), &Company{CompanyID: "27", CustomAttributes: map[string]interface{}{"plna": "pro"}}); err == nil {
+		t.Errorf("Expected an error saving a Company with an unknown custom attribute")
+	}
+}
+
+type TestDataAttributeAPI struct {
+	t          *testing.T
+	lists      int
+	lastParams dataAttributeListParams
+}
+
+func (t *TestDataAttributeAPI) list(ctx context.Context, params dataAttributeListParams) (DataAttributeList, error) {
+	t.lists++
+	t.lastParams = params
+	return DataAttributeList{DataAttributes: []DataAttribute{
+		{Name: "email", DataType: "string", APIWritable: true},
+		{ID: "1", Name: "plan", DataType: "string", APIWritable: true, Custom: true},
+		{ID: "2", Name: "seats", DataType: "integer", APIWritable: true, Custom: true},
+		{ID: "3", Name: "score", DataType: "float", APIWritable: true, Custom: true},
+		{ID: "4", Name: "paid", DataType: "boolean", APIWritable: true, Custom: true},
+		{ID: "5", Name: "renews_at", DataType: "date", APIWritable: true, Custom: true},
+		{ID: "6", Name: "legacy", DataType: "string", APIWritable: true, Custom: true, Archived: true},
+		{ID: "7", Name: "computed", DataType: "integer", Custom: true},
+	}}, nil
+}
+
+func (t *TestDataAttributeAPI) create(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	return *attribute, nil
+}
+
+func (t *TestDataAttributeAPI) update(ctx context.Context, attribute *DataAttribute) (DataAttribute, error) {
+	return *attribute, nil
+}
+
+// TestCreatingDataAttributeAPI creates an attribute while the first schema is being listed.
+type TestCreatingDataAttributeAPI struct {
+	*TestDataAttributeAPI
+	service *DataAttributeService
+}
+
+func (t *TestCreatingDataAttributeAPI) list(ctx context.Context, params dataAttributeListParams) (DataAttributeList, error) {
+	if t.lists == 0 {
+		t.service.Create(ctx, &DataAttribute{Name: "region", Model: "contact", DataType: "string"})
+	}
+	return t.TestDataAttributeAPI.list(ctx, params)
+}
@@ -15,9 +15,13 @@ The first step to using Intercom's Go client is to create a client object, using
 
 The client can be configured with different options by calls to Option:
 
-  ic.Option(intercom.TraceHTTP(true)) // turn http tracing on
+  ic.Option(intercom.SetLogger(slog.Default())) // log requests, redacting personal data
   ic.Option(intercom.BaseURI("http://intercom.dev")) // change the base uri used, useful for testing
   ic.Option(intercom.SetHTTPClient(myHTTPClient)) // set a new HTTP client
+  ic.Option(intercom.APIVersion("1.4")) // pin the Intercom-Version sent with each request
+  ic.Option(intercom.SetRetryPolicy(interfaces.DefaultRetryPolicy())) // retry failed requests
+  ic.Option(intercom.WaitForRateLimit(true)) // wait for the rate limit window to reset when exhausted
+  ic.Option(intercom.Use(myMiddleware)) // wrap every call with interfaces.Middleware
 
 Errors
 
@@ -28,6 +32,12 @@ Errors may be returned from some calls. Errors returned from the API will implem
     fmt.Print(herr)
   }
 
+Or with errors.Is, against ErrNotFound, ErrUnauthorized, ErrConflict and ErrRateLimited:
+
+  if errors.Is(err, intercom.ErrNotFound) {
+    fmt.Print(err)
+  }
+
 HTTP Client
 
 The HTTP Client used by this package can be swapped out for one of your choosing, with your own configuration, it just needs to implement the HTTPClient interface:
@@ -59,5 +69,15 @@ For many resources, pagination should be applied through the use of a PageParams
   }
   ic.Users.List(pageParams)
 
+To walk every page, use an Iterator:
+
+  it := ic.Users.IterateList(ctx, pageParams)
+  for it.Next() {
+    fmt.Print(it.Value())
+  }
+  if err := it.Err(); err != nil {
+    fmt.Print(err)
+  }
+
 */
 package intercom
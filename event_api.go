@@ -17,6 +17,7 @@ type EventAPI struct {
 }
 
 func (api EventAPI) save(ctx context.Context, event *Event) error {
+	ctx = interfaces.WithOperation(ctx, "events", "save")
 	_, err := api.httpClient.Post(ctx, "/events", event)
 	return err
 }
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -11,14 +12,14 @@ func TestEventAPISave(t *testing.T) {
 	http := TestEventHTTPClient{t: t, expectedURI: "/events"}
 	api := EventAPI{httpClient: &http}
 	event := Event{UserID: "27", CreatedAt: int64(time.Now().Unix()), EventName: "govent"}
-	api.save(&event)
+	api.save(context.Background(), &event)
 }
 
 func TestEventAPISaveFail(t *testing.T) {
 	http := TestEventHTTPClient{t: t, expectedURI: "/events", shouldFail: true}
 	api := EventAPI{httpClient: &http}
 	event := Event{UserID: "444", CreatedAt: int64(time.Now().Unix()), EventName: "govent"}
-	err := api.save(&event)
+	err := api.save(context.Background(), &event)
 	if herr, ok := err.(interfaces.HTTPError); ok && herr.Code != "not_found" {
 		t.Errorf("Error not returned")
 	}
@@ -31,7 +32,7 @@ type TestEventHTTPClient struct {
 	shouldFail  bool
 }
 
-func (t TestEventHTTPClient) Post(uri string, event interface{}) ([]byte, error) {
+func (t TestEventHTTPClient) Post(ctx context.Context, uri string, event interface{}) ([]byte, error) {
 	if uri != "/events" {
 		t.t.Errorf("Wrong endpoint called")
 	}
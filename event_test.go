@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -8,7 +9,7 @@ import (
 
 func TestEventSaveFail(t *testing.T) {
 	eventService := EventService{Repository: TestEventAPI{t: t, body: failBody}}
-	err := eventService.Save(&Event{})
+	err := eventService.Save(context.Background(), &Event{})
 	if err.Error() != "Missing Identifier" {
 		t.Errorf("Error not propagated")
 	}
@@ -25,7 +26,7 @@ func TestEventSave(t *testing.T) {
 	event.EventName = "govent"
 	event.CreatedAt = int64(time.Now().Unix())
 	event.Metadata = map[string]interface{}{"is_cool": true}
-	eventService.Save(&event)
+	eventService.Save(context.Background(), &event)
 }
 
 func successBody(t *testing.T, event Event) error {
@@ -46,6 +47,6 @@ type TestEventAPI struct {
 	body func(*testing.T, Event) error
 }
 
-func (t TestEventAPI) save(event *Event) error {
+func (t TestEventAPI) save(ctx context.Context, event *Event) error {
 	return t.body(t.t, *event)
 }
@@ -0,0 +1,333 @@
+package intercom
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ScrollTimeout is how long a scroll may be left idle before Intercom expires it.
+const ScrollTimeout = time.Minute
+
+var (
+	// ErrScrollExpired is returned when an export's scroll has expired, and must be restarted.
+	ErrScrollExpired = errors.New("intercom: scroll expired")
+	// ErrNoCheckpoint is returned when resuming an export that has no checkpoint.
+	ErrNoCheckpoint = errors.New("intercom: no export checkpoint")
+)
+
+// ExportFormat determines how exported objects are written
+type ExportFormat int
+
+const (
+	EXPORT_NDJSON ExportFormat = iota
+	EXPORT_CSV
+)
+
+// ExportCheckpoint records the progress of an export, so that it can be resumed.
+type ExportCheckpoint struct {
+	ScrollParam string `json:"scroll_param,omitempty"`
+	Exported    int64  `json:"exported"`
+	ScrolledAt  int64  `json:"scrolled_at,omitempty"`
+	Done        bool   `json:"done,omitempty"`
+}
+
+// CanResume determines whether the scroll recorded by the checkpoint
+// should still be alive at the given time.
+func (c ExportCheckpoint) CanResume(now time.Time) bool {
+	return !c.Done && c.ScrollParam != "" && now.Before(time.Unix(c.ScrolledAt, 0).Add(ScrollTimeout))
+}
+
+// A CheckpointStore persists ExportCheckpoints.
+// Load should return an empty ExportCheckpoint if none has been saved.
+type CheckpointStore interface {
+	Load() (ExportCheckpoint, error)
+	Save(ExportCheckpoint) error
+}
+
+// FileCheckpointStore is a CheckpointStore that saves checkpoints as JSON to a file.
+type FileCheckpointStore struct {
+	Path string
+}
+
+// Load the checkpoint from the file, if it exists.
+func (s FileCheckpointStore) Load() (ExportCheckpoint, error) {
+	checkpoint := ExportCheckpoint{}
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+// Save the checkpoint, replacing the file atomically.
+func (s FileCheckpointStore) Save(checkpoint ExportCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// An Exporter drains a Scroll API list, writing every object to an io.Writer
+// as newline delimited JSON or CSV. The next page is fetched while the last is
+// written and checkpointed, which must take less than ScrollTimeout per page,
+// or the scroll expires.
+//
+// Intercom moves a scroll forward on the server each time a page is fetched, so
+// a fetched page cannot be fetched again. If writing or checkpointing fails, the
+// Exporter keeps the pages it fetched but did not write, and Resume writes them
+// first. If the process exits instead, those pages are lost, so restart with Export.
+// An Exporter is not safe for concurrent use.
+type Exporter[T any] struct {
+	Format ExportFormat
+	// Checkpoints, if set, saves progress after each page is written.
+	Checkpoints CheckpointStore
+	// CSVHeader and CSVRow determine the columns written in EXPORT_CSV format.
+	CSVHeader []string
+	CSVRow    func(T) []string
+
+	scroll  func(context.Context, string) ([]T, string, error)
+	pending []exportPage[T]
+}
+
+// exportPage is a fetched page, following start objects exported before it.
+type exportPage[T any] struct {
+	start      int64
+	items      []T
+	next       string
+	scrolledAt int64
+	err        error
+}
+
+// NewUserExporter creates an Exporter for all Users for App.
+func NewUserExporter(users *UserService, format ExportFormat) *Exporter[User] {
+	return &Exporter[User]{
+		Format:    format,
+		CSVHeader: userCSVHeader,
+		CSVRow:    userCSVRow,
+		scroll: func(ctx context.Context, scrollParam string) ([]User, string, error) {
+			userList, err := users.Scroll(ctx, scrollParam)
+			return userList.Users, userList.ScrollParam, err
+		},
+	}
+}
+
+// NewCompanyExporter creates an Exporter for all Companies for App.
+func NewCompanyExporter(companies *CompanyService, format ExportFormat) *Exporter[Company] {
+	return &Exporter[Company]{
+		Format:    format,
+		CSVHeader: companyCSVHeader,
+		CSVRow:    companyCSVRow,
+		scroll: func(ctx context.Context, scrollParam string) ([]Company, string, error) {
+			companyList, err := companies.Scroll(ctx, scrollParam)
+			return companyList.Companies, companyList.ScrollParam, err
+		},
+	}
+}
+
+// Export starts a new scroll, writing every object to w.
+func (e *Exporter[T]) Export(ctx context.Context, w io.Writer) (ExportCheckpoint, error) {
+	e.pending = nil
+	return e.export(ctx, w, ExportCheckpoint{}, true)
+}
+
+// Resume continues an export from the saved checkpoint, appending to w.
+// If the scroll has expired ErrScrollExpired is returned, and the export must be restarted with Export.
+func (e *Exporter[T]) Resume(ctx context.Context, w io.Writer) (ExportCheckpoint, error) {
+	if e.Checkpoints == nil {
+		return ExportCheckpoint{}, ErrNoCheckpoint
+	}
+	checkpoint, err := e.Checkpoints.Load()
+	switch {
+	case err != nil:
+		return checkpoint, err
+	case checkpoint.Done:
+		return checkpoint, nil
+	case checkpoint.ScrollParam == "":
+		return checkpoint, ErrNoCheckpoint
+	case !checkpoint.CanResume(time.Now()):
+		return checkpoint, ErrScrollExpired
+	}
+	return e.export(ctx, w, checkpoint, false)
+}
+
+func (e *Exporter[T]) export(ctx context.Context, w io.Writer, checkpoint ExportCheckpoint, writeHeader bool) (ExportCheckpoint, error) {
+	write := e.writer(w, writeHeader)
+	queued := e.takePending(checkpoint)
+	fetched := make(chan exportPage[T], 1)
+	inFlight := false
+	fetch := func(scrollParam string, start int64) {
+		inFlight = true
+		go func() {
+			items, next, err := e.scroll(ctx, scrollParam)
+			fetched <- exportPage[T]{start: start, items: items, next: next, scrolledAt: time.Now().Unix(), err: err}
+		}()
+	}
+	// keep holds unwritten pages, including one being fetched, for Resume.
+	keep := func(pages []exportPage[T]) {
+		if inFlight {
+			if page := <-fetched; page.err == nil {
+				pages = append(pages, page)
+			}
+		}
+		e.pending = pages
+	}
+
+	for !checkpoint.Done {
+		var page exportPage[T]
+		if len(queued) > 0 {
+			page, queued = queued[0], queued[1:]
+		} else {
+			if !inFlight {
+				if err := ctx.Err(); err != nil {
+					return checkpoint, err
+				}
+				fetch(checkpoint.ScrollParam, checkpoint.Exported)
+			}
+			page, inFlight = <-fetched, false
+		}
+		if err := page.err; err != nil {
+			if checkpoint.ScrollParam != "" && errors.Is(err, ErrNotFound) {
+				err = fmt.Errorf("%w: %v", ErrScrollExpired, err)
+			}
+			return checkpoint, err
+		}
+		if len(page.items) > 0 && len(queued) == 0 {
+			fetch(page.next, page.start+int64(len(page.items)))
+		}
+		if len(page.items) == 0 {
+			checkpoint.Done = true
+		} else if err := write(page.items); err != nil {
+			keep(append([]exportPage[T]{page}, queued...))
+			return checkpoint, err
+		}
+		checkpoint.ScrollParam = page.next
+		checkpoint.Exported += int64(len(page.items))
+		checkpoint.ScrolledAt = page.scrolledAt
+		if e.Checkpoints != nil {
+			if err := e.Checkpoints.Save(checkpoint); err != nil {
+				keep(queued)
+				return checkpoint, err
+			}
+		}
+	}
+	return checkpoint, nil
+}
+
+// takePending returns the pages kept from a failed export that follow the checkpoint.
+func (e *Exporter[T]) takePending(checkpoint ExportCheckpoint) []exportPage[T] {
+	pending := e.pending
+	e.pending = nil
+	for len(pending) > 0 && pending[0].start < checkpoint.Exported {
+		pending = pending[1:]
+	}
+	if len(pending) == 0 || pending[0].start != checkpoint.Exported {
+		return nil
+	}
+	return pending
+}
+
+func (e *Exporter[T]) writer(w io.Writer, writeHeader bool) func([]T) error {
+	if e.Format == EXPORT_CSV {
+		csvWriter := csv.NewWriter(w)
+		return func(items []T) error {
+			if writeHeader {
+				csvWriter.Write(e.CSVHeader)
+				writeHeader = false
+			}
+			for _, item := range items {
+				csvWriter.Write(e.CSVRow(item))
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	}
+	encoder := json.NewEncoder(w)
+	return func(items []T) error {
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+var userCSVHeader = []string{"id", "user_id", "email", "name", "phone", "signed_up_at", "created_at", "updated_at", "last_request_at", "session_count", "custom_attributes"}
+
+func userCSVRow(u User) []string {
+	return []string{
+		u.ID,
+		u.UserID,
+		u.Email,
+		u.Name,
+		u.Phone,
+		formatCSVInt(u.SignedUpAt),
+		formatCSVInt(u.CreatedAt),
+		formatCSVInt(u.UpdatedAt),
+		formatCSVInt(u.LastRequestAt),
+		formatCSVInt(u.SessionCount),
+		formatCSVAttributes(u.CustomAttributes),
+	}
+}
+
+var companyCSVHeader = []string{"id", "company_id", "name", "plan", "monthly_spend", "user_count", "session_count", "created_at", "updated_at", "custom_attributes"}
+
+func companyCSVRow(c Company) []string {
+	plan := ""
+	if c.Plan != nil {
+		plan = c.Plan.Name
+	}
+	return []string{
+		c.ID,
+		c.CompanyID,
+		c.Name,
+		plan,
+		formatCSVInt(c.MonthlySpend),
+		formatCSVInt(c.UserCount),
+		formatCSVInt(c.SessionCount),
+		formatCSVInt(c.CreatedAt),
+		formatCSVInt(c.UpdatedAt),
+		formatCSVAttributes(c.CustomAttributes),
+	}
+}
+
+func formatCSVInt(i int64) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+func formatCSVAttributes(attributes map[string]interface{}) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(attributes)
+	return string(data)
+}
@@ -0,0 +1,253 @@
+package intercom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestExportNDJSON(t *testing.T) {
+	store := &TestCheckpointStore{}
+	exporter := NewUserExporter(&UserService{Repository: &TestPagedUserAPI{totalPages: 2}}, EXPORT_NDJSON)
+	exporter.Checkpoints = store
+	buf := &bytes.Buffer{}
+	checkpoint, err := exporter.Export(context.Background(), buf)
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	expected := "{\"id\":\"1a\"}\n{\"id\":\"1b\"}\n{\"id\":\"2a\"}\n{\"id\":\"2b\"}\n"
+	if buf.String() != expected {
+		t.Errorf("Output was %q, expected %q", buf.String(), expected)
+	}
+	if !checkpoint.Done || checkpoint.Exported != 4 {
+		t.Errorf("Checkpoint was %+v, expected 4 exported and done", checkpoint)
+	}
+	if len(store.saved) != 3 || store.saved[0].ScrollParam != "scroll-1" || store.saved[0].Exported != 2 {
+		t.Errorf("Saved checkpoints were %+v", store.saved)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	exporter := NewCompanyExporter(&CompanyService{Repository: TestScrollCompanyAPI{}}, EXPORT_CSV)
+	buf := &bytes.Buffer{}
+	if _, err := exporter.Export(context.Background(), buf); err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Output was %q, expected a header and a row", buf.String())
+	}
+	if lines[0] != strings.Join(companyCSVHeader, ",") {
+		t.Errorf("Header was %s", lines[0])
+	}
+	if lines[1] != `5,c5,My Co,Pro,100,,,,,"{""is_cool"":true}"` {
+		t.Errorf("Row was %s", lines[1])
+	}
+}
+
+func TestExportResume(t *testing.T) {
+	store := &TestCheckpointStore{checkpoint: ExportCheckpoint{ScrollParam: "scroll-1", Exported: 2, ScrolledAt: time.Now().Unix()}}
+	api := &TestPagedUserAPI{totalPages: 2, scrollParams: []string{""}}
+	exporter := NewUserExporter(&UserService{Repository: api}, EXPORT_NDJSON)
+	exporter.Checkpoints = store
+	buf := &bytes.Buffer{}
+	checkpoint, err := exporter.Resume(context.Background(), buf)
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if api.scrollParams[1] != "scroll-1" {
+		t.Errorf("Resumed with %s, expected scroll-1", api.scrollParams[1])
+	}
+	if buf.String() != "{\"id\":\"2a\"}\n{\"id\":\"2b\"}\n" {
+		t.Errorf("Output was %q", buf.String())
+	}
+	if checkpoint.Exported != 4 {
+		t.Errorf("Exported was %d, expected 4", checkpoint.Exported)
+	}
+}
+
+func TestExportResumeExpired(t *testing.T) {
+	store := &TestCheckpointStore{checkpoint: ExportCheckpoint{ScrollParam: "scroll-1", ScrolledAt: time.Now().Add(-2 * time.Minute).Unix()}}
+	exporter := NewUserExporter(&UserService{Repository: &TestPagedUserAPI{}}, EXPORT_NDJSON)
+	exporter.Checkpoints = store
+	if _, err := exporter.Resume(context.Background(), ioutil.Discard); err != ErrScrollExpired {
+		t.Errorf("Error was %v, expected %v", err, ErrScrollExpired)
+	}
+	exporter.Checkpoints = &TestCheckpointStore{}
+	if _, err := exporter.Resume(context.Background(), ioutil.Discard); err != ErrNoCheckpoint {
+		t.Errorf("Error was %v, expected %v", err, ErrNoCheckpoint)
+	}
+}
+
+func TestExportScrollExpiredByAPI(t *testing.T) {
+	exporter := NewUserExporter(&UserService{Repository: &TestExpiringScrollUserAPI{}}, EXPORT_NDJSON)
+	_, err := exporter.Export(context.Background(), ioutil.Discard)
+	if !errors.Is(err, ErrScrollExpired) {
+		t.Errorf("Error was %v, expected %v", err, ErrScrollExpired)
+	}
+}
+
+func TestExportResumeAfterCrash(t *testing.T) {
+	api := &TestStatefulScrollUserAPI{pages: 4}
+	store := &TestCheckpointStore{}
+	exporter := NewUserExporter(&UserService{Repository: api}, EXPORT_NDJSON)
+	exporter.Checkpoints = &TestCrashingCheckpointStore{TestCheckpointStore: store, crashAfter: 2}
+	buf := &bytes.Buffer{}
+	if _, err := exporter.Export(context.Background(), buf); err == nil {
+		t.Fatalf("Expected the export to crash")
+	}
+	if api.served != 3 {
+		t.Errorf("%d pages were fetched before the crash, expected 2 and the next prefetched", api.served)
+	}
+
+	exporter.Checkpoints = store
+	checkpoint, err := exporter.Resume(context.Background(), buf)
+	if err != nil {
+		t.Fatalf("Error resuming was %v", err)
+	}
+	ids := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{"1a", "1b", "2a", "2b", "3a", "3b", "4a", "4b"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Output was %q, expected each of %v once", buf.String(), expected)
+	}
+	for i, id := range expected {
+		if ids[i] != `{"id":"`+id+`"}` {
+			t.Errorf("Record %d was %s, expected %s", i, ids[i], id)
+		}
+	}
+	if !checkpoint.Done || checkpoint.Exported != 8 {
+		t.Errorf("Checkpoint was %+v, expected 8 exported and done", checkpoint)
+	}
+}
+
+func TestExportResumeAfterWriteError(t *testing.T) {
+	api := &TestStatefulScrollUserAPI{pages: 3}
+	store := &TestCheckpointStore{}
+	exporter := NewUserExporter(&UserService{Repository: api}, EXPORT_NDJSON)
+	exporter.Checkpoints = store
+	buf := &bytes.Buffer{}
+	if _, err := exporter.Export(context.Background(), &TestFailingWriter{Writer: buf, failAfter: 2}); err == nil {
+		t.Fatalf("Expected the export to fail")
+	}
+	store.checkpoint = store.saved[len(store.saved)-1]
+	checkpoint, err := exporter.Resume(context.Background(), buf)
+	if err != nil {
+		t.Fatalf("Error resuming was %v", err)
+	}
+	expected := "{\"id\":\"1a\"}\n{\"id\":\"1b\"}\n{\"id\":\"2a\"}\n{\"id\":\"2b\"}\n{\"id\":\"3a\"}\n{\"id\":\"3b\"}\n"
+	if buf.String() != expected {
+		t.Errorf("Output was %q, expected %q", buf.String(), expected)
+	}
+	if !checkpoint.Done || checkpoint.Exported != 6 {
+		t.Errorf("Checkpoint was %+v, expected 6 exported and done", checkpoint)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "intercom-export")
+	defer os.RemoveAll(dir)
+	store := FileCheckpointStore{Path: filepath.Join(dir, "users.checkpoint")}
+	if checkpoint, err := store.Load(); err != nil || checkpoint.ScrollParam != "" {
+		t.Errorf("Loaded %+v, %v, expected an empty checkpoint", checkpoint, err)
+	}
+	store.Save(ExportCheckpoint{ScrollParam: "abc", Exported: 100, ScrolledAt: 1500000000})
+	checkpoint, err := store.Load()
+	if err != nil || checkpoint.ScrollParam != "abc" || checkpoint.Exported != 100 || checkpoint.ScrolledAt != 1500000000 {
+		t.Errorf("Loaded %+v, %v", checkpoint, err)
+	}
+}
+
+type TestCheckpointStore struct {
+	checkpoint ExportCheckpoint
+	saved      []ExportCheckpoint
+}
+
+func (s *TestCheckpointStore) Load() (ExportCheckpoint, error) {
+	return s.checkpoint, nil
+}
+
+func (s *TestCheckpointStore) Save(checkpoint ExportCheckpoint) error {
+	s.saved = append(s.saved, checkpoint)
+	return nil
+}
+
+// TestCrashingCheckpointStore saves checkpoints, then fails as though the process
+// crashed once crashAfter have been saved.
+type TestCrashingCheckpointStore struct {
+	*TestCheckpointStore
+	crashAfter int
+}
+
+func (s *TestCrashingCheckpointStore) Save(checkpoint ExportCheckpoint) error {
+	s.checkpoint = checkpoint
+	s.TestCheckpointStore.Save(checkpoint)
+	if len(s.saved) >= s.crashAfter {
+		return errors.New("crashed")
+	}
+	return nil
+}
+
+// TestFailingWriter fails every write after failAfter have been written.
+type TestFailingWriter struct {
+	io.Writer
+	failAfter int
+	written   int
+}
+
+func (w *TestFailingWriter) Write(p []byte) (int, error) {
+	if w.written >= w.failAfter {
+		return 0, errors.New("disk full")
+	}
+	w.written++
+	return w.Writer.Write(p)
+}
+
+// TestStatefulScrollUserAPI moves its scroll forward on every fetch, as Intercom does,
+// whatever scroll param is sent.
+type TestStatefulScrollUserAPI struct {
+	TestPagedUserAPI
+	pages  int
+	served int
+}
+
+func (t *TestStatefulScrollUserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
+	if t.served >= t.pages {
+		return UserList{ScrollParam: "scroll"}, nil
+	}
+	t.served++
+	page := strconv.Itoa(t.served)
+	return UserList{Users: []User{{ID: page + "a"}, {ID: page + "b"}}, ScrollParam: "scroll"}, nil
+}
+
+type TestExpiringScrollUserAPI struct {
+	TestPagedUserAPI
+}
+
+func (t *TestExpiringScrollUserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
+	if scrollParam != "" {
+		return UserList{}, interfaces.HTTPError{StatusCode: 404, Code: "not_found"}
+	}
+	return UserList{Users: []User{{ID: "1"}}, ScrollParam: "scroll-1"}, nil
+}
+
+type TestScrollCompanyAPI struct {
+	CompanyRepository
+}
+
+func (t TestScrollCompanyAPI) scroll(ctx context.Context, scrollParam string) (CompanyList, error) {
+	if scrollParam != "" {
+		return CompanyList{}, nil
+	}
+	company := Company{ID: "5", CompanyID: "c5", Name: "My Co", Plan: &Plan{Name: "Pro"}, MonthlySpend: 100, CustomAttributes: map[string]interface{}{"is_cool": true}}
+	return CompanyList{Companies: []Company{company}, ScrollParam: "scroll-1"}, nil
+}
@@ -0,0 +1,13 @@
+module github.com/opensimsim/intercom-go
+
+go 1.21
+
+require (
+	github.com/google/go-querystring v1.1.0
+	github.com/pborman/uuid v1.2.1
+)
+
+require (
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+)
@@ -1,8 +1,21 @@
 package intercom
 
+import "context"
+
 type TestHTTPClient struct{}
 
-func (h TestHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) { return nil, nil }
-func (h TestHTTPClient) Post(uri string, body interface{}) ([]byte, error)       { return nil, nil }
-func (h TestHTTPClient) Patch(uri string, body interface{}) ([]byte, error)      { return nil, nil }
-func (h TestHTTPClient) Delete(uri string, body interface{}) ([]byte, error)     { return nil, nil }
+func (h TestHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	return nil, nil
+}
+func (h TestHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return nil, nil
+}
+func (h TestHTTPClient) Patch(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return nil, nil
+}
+func (h TestHTTPClient) Put(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return nil, nil
+}
+func (h TestHTTPClient) Delete(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,65 @@
+package intercom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// An IdentityVerifier generates and checks the user_hash used by the Intercom
+// Messenger to verify the identity of logged in Users.
+type IdentityVerifier struct {
+	secret []byte
+}
+
+// NewIdentityVerifier creates an IdentityVerifier with your app's identity verification secret.
+func NewIdentityVerifier(secret string) IdentityVerifier {
+	return IdentityVerifier{secret: []byte(secret)}
+}
+
+// UserHash generates the user_hash for a User, the HMAC-SHA256 of their UserID,
+// or their Email if they have no UserID.
+func (v IdentityVerifier) UserHash(user *User) (string, error) {
+	return v.UserHashForIdentifiers(UserIdentifiers{UserID: user.UserID, Email: user.Email})
+}
+
+// UserHashForIdentifiers generates the user_hash for a User identified by UserID or Email.
+// The Intercom ID is not used by the Messenger, so cannot be verified.
+func (v IdentityVerifier) UserHashForIdentifiers(identifiers UserIdentifiers) (string, error) {
+	identifier, err := identifiers.messengerIdentifier()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(v.sum(identifier)), nil
+}
+
+// Verify checks that hash is the user_hash for the User.
+func (v IdentityVerifier) Verify(user *User, hash string) bool {
+	identifier, err := UserIdentifiers{UserID: user.UserID, Email: user.Email}.messengerIdentifier()
+	if err != nil {
+		return false
+	}
+	decoded, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(v.sum(identifier), decoded)
+}
+
+func (v IdentityVerifier) sum(identifier string) []byte {
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(identifier))
+	return mac.Sum(nil)
+}
+
+// messengerIdentifier is the identifier the Messenger identifies a User by.
+func (i UserIdentifiers) messengerIdentifier() (string, error) {
+	switch {
+	case i.UserID != "":
+		return i.UserID, nil
+	case i.Email != "":
+		return i.Email, nil
+	}
+	return "", errors.New("Missing User Identifier")
+}
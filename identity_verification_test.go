@@ -0,0 +1,43 @@
+package intercom
+
+import "testing"
+
+func TestUserHashUsesUserID(t *testing.T) {
+	hash, err := NewIdentityVerifier("s3cret").UserHash(&User{UserID: "27", Email: "test@example.com"})
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if hash != "a6a6e8ee1bdfef430f45dd8209658c9012dc1080e2a7cf3e041aa84515aa9ae3" {
+		t.Errorf("UserHash was %s", hash)
+	}
+}
+
+func TestUserHashFallsBackToEmail(t *testing.T) {
+	hash, _ := NewIdentityVerifier("s3cret").UserHash(&User{ID: "46adad3f09126dca", Email: "test@example.com"})
+	if hash != "d5345b9b1469a98d85b899fa23395490b009d09af1a53c77e9d5277e6a63b411" {
+		t.Errorf("UserHash was %s", hash)
+	}
+}
+
+func TestUserHashMissingIdentifier(t *testing.T) {
+	if _, err := NewIdentityVerifier("s3cret").UserHash(&User{ID: "46adad3f09126dca"}); err == nil {
+		t.Errorf("Error not returned for User without UserID or Email")
+	}
+}
+
+func TestUserHashVerify(t *testing.T) {
+	verifier := NewIdentityVerifier("s3cret")
+	user := &User{UserID: "27"}
+	if !verifier.Verify(user, "a6a6e8ee1bdfef430f45dd8209658c9012dc1080e2a7cf3e041aa84515aa9ae3") {
+		t.Errorf("Valid user_hash was not verified")
+	}
+	if verifier.Verify(user, "d5345b9b1469a98d85b899fa23395490b009d09af1a53c77e9d5277e6a63b411") {
+		t.Errorf("user_hash for another User was verified")
+	}
+	if verifier.Verify(user, "not hex") {
+		t.Errorf("Invalid user_hash was verified")
+	}
+	if verifier.Verify(&User{}, "") {
+		t.Errorf("User without identifiers was verified")
+	}
+}
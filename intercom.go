@@ -1,34 +1,47 @@
 package intercom
 
 import (
+	"log/slog"
+
 	"github.com/opensimsim/intercom-go/interfaces"
 )
 
 // A Client manages interacting with the Intercom API.
 type Client struct {
 	// Services for interacting with various resources in Intercom.
-	Admins        AdminService
-	Companies     CompanyService
-	Contacts      ContactService
-	Conversations ConversationService
-	Events        EventService
-	Jobs          JobService
-	Messages      MessageService
-	Segments      SegmentService
-	Tags          TagService
-	Users         UserService
+	Admins         AdminService
+	Companies      CompanyService
+	Contacts       ContactService
+	Conversations  ConversationService
+	Counts         CountService
+	DataAttributes DataAttributeService
+	Events         EventService
+	Jobs           JobService
+	Messages       MessageService
+	Segments       SegmentService
+	Tags           TagService
+	Teams          TeamService
+	Users          UserService
+
+	// UnifiedContacts uses the unified Contacts API of API version 2.0 or newer.
+	UnifiedContacts UnifiedContactService
 
 	// Mappings for resources to API constructs
-	AdminRepository        AdminRepository
-	CompanyRepository      CompanyRepository
-	ContactRepository      ContactRepository
-	ConversationRepository ConversationRepository
-	EventRepository        EventRepository
-	JobRepository          JobRepository
-	MessageRepository      MessageRepository
-	SegmentRepository      SegmentRepository
-	TagRepository          TagRepository
-	UserRepository         UserRepository
+	AdminRepository         AdminRepository
+	CompanyRepository       CompanyRepository
+	ContactRepository       ContactRepository
+	ConversationRepository  ConversationRepository
+	CountRepository         CountRepository
+	DataAttributeRepository DataAttributeRepository
+	EventRepository         EventRepository
+	JobRepository           JobRepository
+	MessageRepository       MessageRepository
+	SegmentRepository       SegmentRepository
+	TagRepository           TagRepository
+	TeamRepository          TeamRepository
+	UserRepository          UserRepository
+
+	UnifiedContactRepository UnifiedContactRepository
 
 	// AppID For Intercom.
 	AppID string
@@ -42,6 +55,15 @@ type Client struct {
 	baseURI       string
 	clientVersion string
 	debug         bool
+	retryPolicy   interfaces.RetryPolicy
+	rateLimiter   interfaces.RateLimiter
+	tokenSource   interfaces.TokenSource
+	apiVersion    string
+	middleware    []interfaces.Middleware
+	logger        interfaces.RequestLogger
+	unified       bool
+	validate      bool
+	schemas       *schemaCache
 }
 
 const (
@@ -51,7 +73,7 @@ const (
 
 type option func(c *Client) option
 
-// Set Options on the Intercom Client, see TraceHTTP, BaseURI and SetHTTPClient.
+// Set Options on the Intercom Client, see SetLogger, LogBodies, BaseURI, APIVersion, SetRetryPolicy, WaitForRateLimit, SetTokenSource, SetHTTPClient, Use, UseUnifiedContacts and ValidateCustomAttributes.
 func (c *Client) Option(opts ...option) (previous option) {
 	for _, opt := range opts {
 		previous = opt(c)
@@ -62,11 +84,25 @@ func (c *Client) Option(opts ...option) (previous option) {
 // NewClient returns a new Intercom API client, configured with the default HTTPClient.
 func NewClient(appID, apiKey string) *Client {
 	intercom := Client{AppID: appID, APIKey: apiKey, baseURI: defaultBaseURI, debug: false, clientVersion: clientVersion}
-	intercom.HTTPClient = interfaces.NewIntercomHTTPClient(intercom.AppID, intercom.APIKey, &intercom.baseURI, &intercom.clientVersion, &intercom.debug)
+	httpClient := interfaces.NewIntercomHTTPClient(intercom.AppID, intercom.APIKey, &intercom.baseURI, &intercom.clientVersion, &intercom.debug)
+	httpClient.RetryPolicy = &intercom.retryPolicy
+	httpClient.RateLimiter = &intercom.rateLimiter
+	httpClient.TokenSource = &intercom.tokenSource
+	httpClient.Version = &intercom.apiVersion
+	httpClient.Logger = &intercom.logger
+	intercom.HTTPClient = httpClient
 	intercom.setup()
 	return &intercom
 }
 
+// NewClientWithToken returns a new Intercom API client, configured with the default HTTPClient,
+// that authenticates using an OAuth access token sent as a Bearer token.
+func NewClientWithToken(token string) *Client {
+	intercom := NewClient("", "")
+	intercom.Option(SetTokenSource(interfaces.StaticTokenSource(token)))
+	return intercom
+}
+
 // NewClientWithHTTPClient returns a new Intercom API client, configured with the supplied HTTPClient interface
 func NewClientWithHTTPClient(appID, apiKey string, httpClient interfaces.HTTPClient) *Client {
 	intercom := Client{AppID: appID, APIKey: apiKey, baseURI: defaultBaseURI, debug: false, clientVersion: clientVersion, HTTPClient: httpClient}
@@ -75,6 +111,9 @@ func NewClientWithHTTPClient(appID, apiKey string, httpClient interfaces.HTTPCli
 }
 
 // TraceHTTP turns on HTTP request/response tracing for debugging.
+// Requests and responses are printed to stdout in full, including personal data.
+//
+// Deprecated: use SetLogger, with LogBodies to include redacted bodies.
 func TraceHTTP(trace bool) option {
 	return func(c *Client) option {
 		previous := c.debug
@@ -83,6 +122,28 @@ func TraceHTTP(trace bool) option {
 	}
 }
 
+// SetLogger logs each request made by the default HTTPClient to logger, with its method, path,
+// status, latency, request ID and retry count. Bodies are only logged with LogBodies.
+// A nil logger turns logging off.
+func SetLogger(logger *slog.Logger) option {
+	return func(c *Client) option {
+		previous := c.logger.Logger
+		c.logger.Logger = logger
+		return SetLogger(previous)
+	}
+}
+
+// LogBodies adds the query, request headers and request and response bodies to each log made
+// with SetLogger. Authorization headers and fields in interfaces.DefaultRedactedFields, such as
+// email and custom_attributes, are redacted.
+func LogBodies(log bool) option {
+	return func(c *Client) option {
+		previous := c.logger.LogBodies
+		c.logger.LogBodies = log
+		return LogBodies(previous)
+	}
+}
+
 // BaseURI sets a base URI for the HTTP Client to use. Defaults to "https://api.intercom.io".
 // Typically this would be used during testing to point to a stubbed service.
 func BaseURI(baseURI string) option {
@@ -93,6 +154,48 @@ func BaseURI(baseURI string) option {
 	}
 }
 
+// SetRetryPolicy sets the RetryPolicy used by the default HTTPClient when
+// requests fail with a retryable status code or a connection error.
+// By default no requests are retried, see interfaces.DefaultRetryPolicy.
+func SetRetryPolicy(policy interfaces.RetryPolicy) option {
+	return func(c *Client) option {
+		previous := c.retryPolicy
+		c.retryPolicy = policy
+		return SetRetryPolicy(previous)
+	}
+}
+
+// WaitForRateLimit makes the default HTTPClient block requests once the
+// current rate limit window is exhausted, until the window resets.
+// The wait is abandoned if the request's context is done.
+func WaitForRateLimit(wait bool) option {
+	return func(c *Client) option {
+		return WaitForRateLimit(c.rateLimiter.SetWait(wait))
+	}
+}
+
+// SetTokenSource sets a TokenSource for the default HTTPClient to take an OAuth access token from
+// for each request, sent as a Bearer token. Useful when tokens are refreshed or rotated.
+// When no TokenSource is set, the AppID and APIKey are used for Basic auth.
+func SetTokenSource(tokenSource interfaces.TokenSource) option {
+	return func(c *Client) option {
+		previous := c.tokenSource
+		c.tokenSource = tokenSource
+		return SetTokenSource(previous)
+	}
+}
+
+// APIVersion pins the Intercom-Version sent by the default HTTPClient with every request,
+// such as "1.4" or "2.0". By default no version is sent, and the workspace's default version is used.
+// Requests for endpoints that need a newer version than the one pinned fail with an interfaces.VersionError.
+func APIVersion(version string) option {
+	return func(c *Client) option {
+		previous := c.apiVersion
+		c.apiVersion = version
+		return APIVersion(previous)
+	}
+}
+
 // SetHTTPClient sets a HTTPClient for the Intercom Client to use.
 // Useful for customising timeout behaviour etc.
 func SetHTTPClient(httpClient interfaces.HTTPClient) option {
@@ -104,25 +207,96 @@ func SetHTTPClient(httpClient interfaces.HTTPClient) option {
 	}
 }
 
+// Use adds middleware around the HTTPClient, which sees every call made by the repositories.
+// Middleware added first is outermost. Middleware is kept when the HTTPClient is changed with SetHTTPClient.
+func Use(middleware ...interfaces.Middleware) option {
+	return func(c *Client) option {
+		previous := c.middleware
+		return setMiddleware(append(previous[:len(previous):len(previous)], middleware...))(c)
+	}
+}
+
+func setMiddleware(middleware []interfaces.Middleware) option {
+	return func(c *Client) option {
+		previous := c.middleware
+		c.middleware = middleware
+		c.setup()
+		return setMiddleware(previous)
+	}
+}
+
+// UseUnifiedContacts backs Users and Contacts with the unified Contacts API, as Contacts with the
+// user and lead roles, so existing callers keep working while migrating to UnifiedContacts.
+// Lists are searches, so fetching page N by number takes N requests; use IterateList, or pass the
+// previous page's Pages.Next as PageParams.Next, to fetch each page with one. Requires API version 2.0 or newer.
+func UseUnifiedContacts(unified bool) option {
+	return func(c *Client) option {
+		previous := c.unified
+		c.unified = unified
+		c.setup()
+		return UseUnifiedContacts(previous)
+	}
+}
+
+// ValidateCustomAttributes makes Users.Save and Companies.Save check custom attributes against the
+// schema listed by DataAttributes before sending them, returning a CustomAttributeError for any
+// that are unknown or of the wrong type. The schema is cached until DataAttributes.ClearSchemas.
+// Requires API version 2.0 or newer, where Users are Contacts, so pairs with UseUnifiedContacts;
+// when pinned to an older version, Save returns a VersionError.
+func ValidateCustomAttributes(validate bool) option {
+	return func(c *Client) option {
+		previous := c.validate
+		c.validate = validate
+		c.setup()
+		return ValidateCustomAttributes(previous)
+	}
+}
+
+// RateLimit returns the rate limit window last reported by the API to the default HTTPClient.
+// The Reset time is when Remaining requests will be replenished.
+func (c *Client) RateLimit() interfaces.RateLimit {
+	return c.rateLimiter.RateLimit()
+}
+
 func (c *Client) setup() {
-	c.AdminRepository = AdminAPI{httpClient: c.HTTPClient}
-	c.CompanyRepository = CompanyAPI{httpClient: c.HTTPClient}
-	c.ContactRepository = ContactAPI{httpClient: c.HTTPClient}
-	c.ConversationRepository = ConversationAPI{httpClient: c.HTTPClient}
-	c.EventRepository = EventAPI{httpClient: c.HTTPClient}
-	c.JobRepository = JobAPI{httpClient: c.HTTPClient}
-	c.MessageRepository = MessageAPI{httpClient: c.HTTPClient}
-	c.SegmentRepository = SegmentAPI{httpClient: c.HTTPClient}
-	c.TagRepository = TagAPI{httpClient: c.HTTPClient}
-	c.UserRepository = UserAPI{httpClient: c.HTTPClient}
+	httpClient := interfaces.Chain(c.HTTPClient, c.middleware...)
+	c.AdminRepository = AdminAPI{httpClient: httpClient}
+	c.CompanyRepository = CompanyAPI{httpClient: httpClient}
+	c.ContactRepository = ContactAPI{httpClient: httpClient}
+	c.ConversationRepository = ConversationAPI{httpClient: httpClient}
+	c.CountRepository = CountAPI{httpClient: httpClient}
+	c.DataAttributeRepository = DataAttributeAPI{httpClient: httpClient}
+	c.EventRepository = EventAPI{httpClient: httpClient}
+	c.JobRepository = JobAPI{httpClient: httpClient}
+	c.MessageRepository = MessageAPI{httpClient: httpClient}
+	c.SegmentRepository = SegmentAPI{httpClient: httpClient}
+	c.TagRepository = TagAPI{httpClient: httpClient}
+	c.TeamRepository = TeamAPI{httpClient: httpClient}
+	c.UserRepository = UserAPI{httpClient: httpClient}
+	c.UnifiedContactRepository = UnifiedContactAPI{httpClient: httpClient}
+	if c.unified {
+		c.ContactRepository = NewUnifiedLeadAPI(c.UnifiedContactRepository)
+		c.UserRepository = NewUnifiedUserAPI(c.UnifiedContactRepository)
+	}
 	c.Admins = AdminService{Repository: c.AdminRepository}
 	c.Companies = CompanyService{Repository: c.CompanyRepository}
 	c.Contacts = ContactService{Repository: c.ContactRepository}
 	c.Conversations = ConversationService{Repository: c.ConversationRepository}
+	c.Counts = CountService{Repository: c.CountRepository}
+	if c.schemas == nil {
+		c.schemas = &schemaCache{}
+	}
+	c.DataAttributes = DataAttributeService{Repository: c.DataAttributeRepository, schemas: c.schemas}
 	c.Events = EventService{Repository: c.EventRepository}
 	c.Jobs = JobService{Repository: c.JobRepository}
 	c.Messages = MessageService{Repository: c.MessageRepository}
 	c.Segments = SegmentService{Repository: c.SegmentRepository}
 	c.Tags = TagService{Repository: c.TagRepository}
+	c.Teams = TeamService{Repository: c.TeamRepository}
 	c.Users = UserService{Repository: c.UserRepository}
+	c.UnifiedContacts = UnifiedContactService{Repository: c.UnifiedContactRepository}
+	if c.validate {
+		c.Users.DataAttributes = &c.DataAttributes
+		c.Companies.DataAttributes = &c.DataAttributes
+	}
 }
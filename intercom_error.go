@@ -1,5 +1,7 @@
 package intercom
 
+import "github.com/opensimsim/intercom-go/interfaces"
+
 // IntercomError is a known error from the Intercom API
 type IntercomError interface {
 	Error() string
@@ -7,3 +9,15 @@ type IntercomError interface {
 	GetCode() string
 	GetMessage() string
 }
+
+// HTTPError is the IntercomError returned by the default HTTPClient.
+// It can be retrieved with errors.As to inspect every error returned, the request ID, and the method and path called.
+type HTTPError = interfaces.HTTPError
+
+// Errors from the API can be checked against these with errors.Is.
+var (
+	ErrUnauthorized = interfaces.ErrUnauthorized
+	ErrNotFound     = interfaces.ErrNotFound
+	ErrConflict     = interfaces.ErrConflict
+	ErrRateLimited  = interfaces.ErrRateLimited
+)
@@ -0,0 +1,85 @@
+package intercom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestNewClientWithToken(t *testing.T) {
+	authorization := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"type":"admin.list","admins":[]}`))
+	}))
+	defer server.Close()
+	ic := NewClientWithToken("abc123")
+	ic.Option(BaseURI(server.URL))
+	if _, err := ic.Admins.List(context.Background()); err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if authorization != "Bearer abc123" {
+		t.Errorf("Authorization was %s, expected Bearer abc123", authorization)
+	}
+}
+
+func TestUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type":"admin.list","admins":[]}`))
+	}))
+	defer server.Close()
+	calls := []interfaces.Call{}
+	recording := func(next interfaces.RoundTrip) interfaces.RoundTrip {
+		return func(ctx context.Context, call interfaces.Call) ([]byte, error) {
+			calls = append(calls, call)
+			return next(ctx, call)
+		}
+	}
+	ic := NewClient("appID", "apiKey")
+	ic.Option(BaseURI(server.URL))
+	previous := ic.Option(Use(recording))
+	ic.Admins.List(context.Background())
+	ic.Option(SetHTTPClient(ic.HTTPClient))
+	ic.Admins.List(context.Background())
+	if len(calls) != 2 || calls[0].Method != "GET" || calls[0].Path != "/admins" {
+		t.Errorf("Calls were %+v", calls)
+	}
+	ic.Option(previous)
+	ic.Admins.List(context.Background())
+	if len(calls) != 2 {
+		t.Errorf("Middleware was still used after being removed")
+	}
+}
+
+func TestUseUnifiedContacts(t *testing.T) {
+	ic := NewClient("appID", "apiKey")
+	previous := ic.Option(UseUnifiedContacts(true))
+	if _, ok := ic.Users.Repository.(UnifiedUserAPI); !ok {
+		t.Errorf("Users Repository was %T, expected UnifiedUserAPI", ic.Users.Repository)
+	}
+	if _, ok := ic.Contacts.Repository.(UnifiedLeadAPI); !ok {
+		t.Errorf("Contacts Repository was %T, expected UnifiedLeadAPI", ic.Contacts.Repository)
+	}
+	ic.Option(previous)
+	if _, ok := ic.Users.Repository.(UserAPI); !ok {
+		t.Errorf("Users Repository was %T after restoring, expected UserAPI", ic.Users.Repository)
+	}
+}
+
+func TestValidateCustomAttributesOption(t *testing.T) {
+	ic := NewClient("appID", "apiKey")
+	if ic.Users.DataAttributes != nil || ic.Companies.DataAttributes != nil {
+		t.Errorf("Custom attributes were validated by default")
+	}
+	previous := ic.Option(ValidateCustomAttributes(true))
+	if ic.Users.DataAttributes != &ic.DataAttributes || ic.Companies.DataAttributes != &ic.DataAttributes {
+		t.Errorf("Custom attributes were not validated with the Client's DataAttributes")
+	}
+	ic.Option(previous)
+	if ic.Users.DataAttributes != nil {
+		t.Errorf("Custom attributes were still validated after restoring")
+	}
+}
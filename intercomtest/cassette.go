@@ -0,0 +1,334 @@
+package intercomtest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/google/go-querystring/query"
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// ErrInteractionNotFound is returned by a Replayer for a request that was not recorded.
+var ErrInteractionNotFound = errors.New("intercomtest: no recorded interaction")
+
+// redactedHeaders are never written to a cassette.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// An Interaction is a request and its response, as stored in a cassette.
+// Each line of a cassette file is one Interaction.
+type Interaction struct {
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Query        string          `json:"query,omitempty"`
+	Body         json.RawMessage `json:"body,omitempty"`
+	Response     json.RawMessage `json:"response,omitempty"`
+	ResponseText string          `json:"response_text,omitempty"`
+	Error        *RecordedError  `json:"error,omitempty"`
+}
+
+// RecordedError is an error returned for an Interaction.
+// Errors other than an interfaces.HTTPError only have a Message.
+type RecordedError struct {
+	StatusCode int                    `json:"status_code,omitempty"`
+	Code       string                 `json:"code,omitempty"`
+	Message    string                 `json:"message"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Errors     []interfaces.HTTPError `json:"errors,omitempty"`
+	Header     http.Header            `json:"header,omitempty"`
+}
+
+// RedactEmails replaces each email address with a placeholder derived from it,
+// so that the same address is always replaced with the same placeholder.
+func RedactEmails(s string) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		sum := sha256.Sum256([]byte(email))
+		return fmt.Sprintf("redacted-%x@example.com", sum[:4])
+	})
+}
+
+// A Recorder is an interfaces.HTTPClient that passes requests to another
+// HTTPClient, writing each Interaction to a cassette file.
+// Authorization headers and email addresses are redacted before writing.
+//
+//  recorder, _ := intercomtest.NewRecorder("fixtures/users.jsonl", ic.HTTPClient)
+//  defer recorder.Close()
+//  ic.Option(intercom.SetHTTPClient(recorder))
+type Recorder struct {
+	// Redact is applied to the query, body and response of each Interaction, defaulting to RedactEmails.
+	Redact func(string) string
+
+	httpClient interfaces.HTTPClient
+	mu         sync.Mutex
+	file       *os.File
+	encoder    *json.Encoder
+}
+
+// NewRecorder creates the cassette file at path, replacing any existing file,
+// and returns a Recorder passing requests to httpClient.
+func NewRecorder(path string, httpClient interfaces.HTTPClient) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{Redact: RedactEmails, httpClient: httpClient, file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// Close closes the cassette file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// APIVersion returns the API version of the wrapped HTTPClient, if it has one.
+func (r *Recorder) APIVersion() string {
+	if versioned, ok := r.httpClient.(interface{ APIVersion() string }); ok {
+		return versioned.APIVersion()
+	}
+	return ""
+}
+
+func (r *Recorder) Get(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	data, err := r.httpClient.Get(ctx, url, queryParams)
+	return data, r.record("GET", url, queryParams, nil, data, err)
+}
+
+func (r *Recorder) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	data, err := r.httpClient.Post(ctx, url, body)
+	return data, r.record("POST", url, nil, body, data, err)
+}
+
+func (r *Recorder) Patch(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	data, err := r.httpClient.Patch(ctx, url, body)
+	return data, r.record("PATCH", url, nil, body, data, err)
+}
+
+func (r *Recorder) Put(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	data, err := interfaces.Put(ctx, r.httpClient, url, body)
+	return data, r.record("PUT", url, nil, body, data, err)
+}
+
+func (r *Recorder) Delete(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	data, err := r.httpClient.Delete(ctx, url, queryParams)
+	return data, r.record("DELETE", url, queryParams, nil, data, err)
+}
+
+// record writes the Interaction, returning the request error unchanged.
+// Failing to write the cassette is returned in preference, so that a recording is never silently incomplete.
+func (r *Recorder) record(method, path string, queryParams, body interface{}, data []byte, err error) error {
+	interaction, ierr := newInteraction(method, path, queryParams, body, r.Redact)
+	if ierr != nil {
+		return ierr
+	}
+	if err != nil {
+		interaction.Error = newRecordedError(err)
+	} else if len(data) > 0 {
+		redacted := []byte(r.Redact(string(data)))
+		if json.Valid(redacted) {
+			interaction.Response = redacted
+		} else {
+			interaction.ResponseText = string(redacted)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if werr := r.encoder.Encode(interaction); werr != nil {
+		return werr
+	}
+	return err
+}
+
+func newRecordedError(err error) *RecordedError {
+	var herr interfaces.HTTPError
+	if !errors.As(err, &herr) {
+		return &RecordedError{Message: err.Error()}
+	}
+	header := http.Header{}
+	for k, v := range herr.GetHeader() {
+		header[k] = v
+	}
+	for _, k := range redactedHeaders {
+		if header.Get(k) != "" {
+			header.Set(k, "REDACTED")
+		}
+	}
+	return &RecordedError{
+		StatusCode: herr.StatusCode,
+		Code:       herr.Code,
+		Message:    herr.Message,
+		RequestID:  herr.RequestID,
+		Errors:     herr.GetErrors(),
+		Header:     header,
+	}
+}
+
+// A Replayer is an interfaces.HTTPClient that answers requests from a cassette file
+// written by a Recorder, without making any requests.
+//
+// Requests are matched on method, path, query and body, after redaction.
+// Matching Interactions are replayed in the order they were recorded,
+// with the last repeated once they are used up.
+type Replayer struct {
+	// Redact must match the Redact used when recording, defaulting to RedactEmails.
+	Redact func(string) string
+
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	used         map[string]int
+}
+
+// NewReplayer loads the cassette file at path.
+func NewReplayer(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &Replayer{Redact: RedactEmails, interactions: map[string][]Interaction{}, used: map[string]int{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		interaction := Interaction{}
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("intercomtest: reading %s: %v", path, err)
+		}
+		key := interaction.key()
+		r.interactions[key] = append(r.interactions[key], interaction)
+	}
+	return r, scanner.Err()
+}
+
+func (r *Replayer) Get(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	return r.replay("GET", url, queryParams, nil)
+}
+
+func (r *Replayer) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return r.replay("POST", url, nil, body)
+}
+
+func (r *Replayer) Patch(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return r.replay("PATCH", url, nil, body)
+}
+
+func (r *Replayer) Put(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return r.replay("PUT", url, nil, body)
+}
+
+func (r *Replayer) Delete(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	return r.replay("DELETE", url, queryParams, nil)
+}
+
+func (r *Replayer) replay(method, path string, queryParams, body interface{}) ([]byte, error) {
+	request, err := newInteraction(method, path, queryParams, body, r.Redact)
+	if err != nil {
+		return nil, err
+	}
+	key := request.key()
+	r.mu.Lock()
+	recorded := r.interactions[key]
+	if len(recorded) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w for %s %s", ErrInteractionNotFound, method, request.url())
+	}
+	i := r.used[key]
+	if i < len(recorded)-1 {
+		r.used[key]++
+	}
+	r.mu.Unlock()
+
+	interaction := recorded[i]
+	if interaction.Error != nil {
+		return nil, interaction.Error.error(method, path)
+	}
+	if interaction.ResponseText != "" {
+		return []byte(interaction.ResponseText), nil
+	}
+	return interaction.Response, nil
+}
+
+func (e *RecordedError) error(method, path string) error {
+	if e.StatusCode == 0 {
+		return errors.New(e.Message)
+	}
+	return interfaces.HTTPError{
+		StatusCode: e.StatusCode,
+		Code:       e.Code,
+		Message:    e.Message,
+		RequestID:  e.RequestID,
+		Method:     method,
+		Path:       path,
+		Details:    &interfaces.HTTPErrorDetails{Errors: e.Errors, Header: e.Header},
+	}
+}
+
+// newInteraction builds the request half of an Interaction, in the canonical form used for matching.
+func newInteraction(method, path string, queryParams, body interface{}, redact func(string) string) (Interaction, error) {
+	if redact == nil {
+		redact = RedactEmails
+	}
+	interaction := Interaction{Method: method, Path: path}
+	if queryParams != nil {
+		values, err := query.Values(queryParams)
+		if err != nil {
+			return interaction, err
+		}
+		for k, vs := range values {
+			for i := range vs {
+				vs[i] = redact(vs[i])
+			}
+			values[k] = vs
+		}
+		interaction.Query = values.Encode()
+	}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return interaction, err
+		}
+		if interaction.Body, err = canonicalJSON([]byte(redact(string(data)))); err != nil {
+			return interaction, err
+		}
+	}
+	return interaction, nil
+}
+
+func (i Interaction) url() string {
+	if i.Query == "" {
+		return i.Path
+	}
+	return i.Path + "?" + i.Query
+}
+
+func (i Interaction) key() string {
+	body, _ := canonicalJSON(i.Body)
+	return i.Method + " " + i.url() + " " + string(body)
+}
+
+// canonicalJSON re-encodes JSON with object keys sorted, so equivalent bodies compare equal.
+func canonicalJSON(data []byte) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
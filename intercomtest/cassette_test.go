@@ -0,0 +1,94 @@
+package intercomtest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	intercom "github.com/opensimsim/intercom-go"
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	ic := server.Client()
+	ic.Option(intercom.SetRetryPolicy(interfaces.RetryPolicy{MaxAttempts: 1}))
+	recorder, err := NewRecorder(path, ic.HTTPClient)
+	if err != nil {
+		t.Fatalf("Error creating Recorder: %v", err)
+	}
+	ic.Option(intercom.SetHTTPClient(recorder))
+	saved, _ := ic.Users.Save(ctx, &intercom.User{UserID: "27", Email: "jamie@example.io"})
+	ic.Users.Save(ctx, &intercom.User{UserID: "27", Name: "Jamie"})
+	ic.Users.FindByEmail(ctx, "jamie@example.io")
+	server.Fail("GET", "/users/"+saved.ID, 1, 404, "not_found")
+	ic.Users.FindByID(ctx, saved.ID)
+	recorder.Close()
+
+	data, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(data), "jamie@example.io") {
+		t.Errorf("Cassette contained an unredacted email:\n%s", data)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Errorf("Cassette had %d interactions, expected 4", lines)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("Error creating Replayer: %v", err)
+	}
+	ic = intercom.NewClient("", "")
+	ic.Option(intercom.SetHTTPClient(replayer))
+	user, err := ic.Users.Save(ctx, &intercom.User{Email: "jamie@example.io", UserID: "27"})
+	if err != nil || user.ID != saved.ID || user.Email != RedactEmails("jamie@example.io") {
+		t.Errorf("Replayed User was %+v, error %v", user, err)
+	}
+	if user, _ := ic.Users.Save(ctx, &intercom.User{UserID: "27", Name: "Jamie"}); user.Name != "Jamie" {
+		t.Errorf("Replayed User was %+v, expected Name Jamie", user)
+	}
+	if user, _ := ic.Users.FindByEmail(ctx, "jamie@example.io"); user.ID != saved.ID {
+		t.Errorf("Replayed User was %+v, expected ID %s", user, saved.ID)
+	}
+	if _, err := ic.Users.FindByID(ctx, saved.ID); !errors.Is(err, intercom.ErrNotFound) {
+		t.Errorf("Replayed error was %v, expected %v", err, intercom.ErrNotFound)
+	}
+	if _, err := ic.Users.FindByUserID(ctx, "28"); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Error was %v, expected %v", err, ErrInteractionNotFound)
+	}
+}
+
+func TestReplayRepeatsLastInteraction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	ioutil.WriteFile(path, []byte(`{"method":"GET","path":"/admins","response":{"admins":[{"id":"1"}]}}
+{"method":"GET","path":"/admins","response":{"admins":[{"id":"2"}]}}
+`), 0644)
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("Error creating Replayer: %v", err)
+	}
+	ic := intercom.NewClient("", "")
+	ic.Option(intercom.SetHTTPClient(replayer))
+	for _, expected := range []string{"1", "2", "2"} {
+		admins, _ := ic.Admins.List(context.Background())
+		if len(admins.Admins) != 1 || admins.Admins[0].ID.String() != expected {
+			t.Errorf("Replayed Admins were %+v, expected ID %s", admins.Admins, expected)
+		}
+	}
+}
+
+func TestRedactEmails(t *testing.T) {
+	redacted := RedactEmails(`{"email":"jamie@example.io","other":"kim@example.io"}`)
+	if strings.Contains(redacted, "jamie") || strings.Contains(redacted, "kim") {
+		t.Errorf("Redacted was %s", redacted)
+	}
+	if RedactEmails("jamie@example.io") == RedactEmails("kim@example.io") {
+		t.Errorf("Different emails were redacted the same")
+	}
+}
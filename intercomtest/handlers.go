@@ -0,0 +1,1056 @@
+package intercomtest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	intercom "github.com/opensimsim/intercom-go"
+)
+
+// userRequest is a User or Contact as sent to the API, with companies as references.
+type userRequest struct {
+	intercom.User
+	Companies []intercom.UserCompany `json:"companies,omitempty"`
+}
+
+// companyRequest is a Company as sent to the API, with the plan as a name.
+type companyRequest struct {
+	intercom.Company
+	Plan string `json:"plan,omitempty"`
+}
+
+type taggingRequest struct {
+	ID        string             `json:"id,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Users     []intercom.Tagging `json:"users,omitempty"`
+	Companies []intercom.Tagging `json:"companies,omitempty"`
+}
+
+type jobRequest struct {
+	JobData *intercom.JobData `json:"job,omitempty"`
+	Items   []struct {
+		Method   string          `json:"method"`
+		DataType string          `json:"data_type"`
+		Data     json.RawMessage `json:"data"`
+	} `json:"items,omitempty"`
+}
+
+type convertRequest struct {
+	User    userRequest `json:"user"`
+	Contact userRequest `json:"contact"`
+}
+
+// route dispatches a request, returning the status code and the response,
+// or the Intercom error code for statuses of 400 and above.
+func (s *Server) route(r *request) (int, interface{}) {
+	switch {
+	case r.is("GET", "users"):
+		return s.getUsers(r)
+	case r.is("GET", "users", "scroll"):
+		start, end, scrollParam := r.scroll(len(s.users))
+		return http.StatusOK, map[string]interface{}{"type": "user.list", "users": s.users[start:end], "scroll_param": scrollParam}
+	case r.is("POST", "users"):
+		return s.postUser(r)
+	case r.is("GET", "users", "*"):
+		if user := s.findUser(intercom.UserIdentifiers{ID: r.path[1]}); user != nil {
+			return http.StatusOK, user
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("DELETE", "users", "*"):
+		if user := s.deleteUser(intercom.UserIdentifiers{ID: r.path[1]}); user != nil {
+			return http.StatusOK, user
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "contacts"):
+		return s.getContacts(r)
+	case r.is("GET", "contacts", "scroll"):
+		start, end, scrollParam := r.scroll(len(s.contacts))
+		return http.StatusOK, map[string]interface{}{"type": "contact.list", "contacts": s.contacts[start:end], "scroll_param": scrollParam}
+	case r.is("POST", "contacts"):
+		return s.postContact(r)
+	case r.is("POST", "contacts", "search"):
+		return search(r, "list", "data", s.unifiedContacts())
+	case r.is("POST", "contacts", "convert"):
+		return s.convertContact(r)
+	case r.is("GET", "contacts", "*"):
+		if i := s.contactIndex(r.path[1], ""); i >= 0 {
+			return http.StatusOK, s.contacts[i]
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("DELETE", "contacts", "*"):
+		if i := s.contactIndex(r.path[1], ""); i >= 0 {
+			contact := s.contacts[i]
+			s.contacts = append(s.contacts[:i], s.contacts[i+1:]...)
+			return http.StatusOK, contact
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "companies"):
+		return s.getCompanies(r)
+	case r.is("GET", "companies", "scroll"):
+		start, end, scrollParam := r.scroll(len(s.companies))
+		return http.StatusOK, map[string]interface{}{"type": "company.list", "companies": s.companies[start:end], "scroll_param": scrollParam}
+	case r.is("POST", "companies"):
+		return s.postCompany(r)
+	case r.is("GET", "companies", "*"):
+		if company := s.findCompany(r.path[1], "", ""); company != nil {
+			return http.StatusOK, company
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("GET", "companies", "*", "users"):
+		if company := s.findCompany(r.path[1], "", ""); company != nil {
+			return s.companyUsers(r, company)
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "conversations"):
+		return s.getConversations(r)
+	case r.is("GET", "conversations", "*"):
+		if convo := s.findConversation(r.path[1]); convo != nil {
+			return http.StatusOK, convo
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("POST", "conversations"):
+		return s.createConversation(r)
+	case r.is("POST", "conversations", "redact"):
+		return s.redact(r)
+	case r.is("POST", "conversations", "search"):
+		return search(r, "conversation.list", "conversations", s.conversations)
+	case r.is("POST", "conversations", "*"):
+		if convo := s.findConversation(r.path[1]); convo != nil {
+			convo.Read = true
+			return http.StatusOK, convo
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("POST", "conversations", "*", "reply"):
+		return s.replyToConversation(r)
+	case r.is("POST", "conversations", "*", "run_assignment_rules"):
+		if convo := s.findConversation(r.path[1]); convo != nil {
+			return http.StatusOK, convo
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "counts"):
+		return s.counts(r)
+
+	case r.is("GET", "tags"):
+		return http.StatusOK, map[string]interface{}{"type": "tag.list", "tags": s.tags}
+	case r.is("POST", "tags"):
+		return s.postTag(r)
+	case r.is("DELETE", "tags", "*"):
+		for i, tag := range s.tags {
+			if tag.ID == r.path[1] {
+				s.tags = append(s.tags[:i], s.tags[i+1:]...)
+				return http.StatusOK, tag
+			}
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "segments"):
+		return http.StatusOK, map[string]interface{}{"type": "segment.list", "segments": s.segments}
+	case r.is("GET", "segments", "*"):
+		for _, segment := range s.segments {
+			if segment.ID == r.path[1] {
+				return http.StatusOK, segment
+			}
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "admins"):
+		return http.StatusOK, map[string]interface{}{"type": "admin.list", "admins": s.admins}
+	case r.is("GET", "admins", "*"):
+		if admin := s.findStoredAdmin(r.path[1]); admin != nil {
+			return http.StatusOK, admin
+		}
+		return http.StatusNotFound, "not_found"
+	case r.is("PUT", "admins", "*", "away"):
+		admin := s.findStoredAdmin(r.path[1])
+		if admin == nil {
+			return http.StatusNotFound, "not_found"
+		}
+		away := struct {
+			AwayModeEnabled  bool `json:"away_mode_enabled"`
+			AwayModeReassign bool `json:"away_mode_reassign"`
+		}{}
+		if err := r.decode(&away); err != nil {
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+		admin.AwayModeEnabled, admin.AwayModeReassign = away.AwayModeEnabled, away.AwayModeReassign
+		return http.StatusOK, admin
+	case r.is("GET", "me"):
+		if len(s.admins) == 0 {
+			return http.StatusNotFound, "not_found"
+		}
+		return http.StatusOK, struct {
+			intercom.Admin
+			App intercom.App `json:"app"`
+		}{s.admins[0], intercom.App{IDCode: "intercomtest", Name: "intercomtest"}}
+
+	case r.is("GET", "data_attributes"):
+		attributes := []*intercom.DataAttribute{}
+		for _, attribute := range s.attributes {
+			if attribute.Model == r.param("model") && (!attribute.Archived || r.param("include_archived") == "true") {
+				attributes = append(attributes, attribute)
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"type": "list", "data": attributes}
+	case r.is("POST", "data_attributes"):
+		return s.saveDataAttribute(r, nil)
+	case r.is("PUT", "data_attributes", "*"):
+		for _, attribute := range s.attributes {
+			if attribute.ID.String() == r.path[1] {
+				return s.saveDataAttribute(r, attribute)
+			}
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("GET", "teams"):
+		return http.StatusOK, map[string]interface{}{"type": "team.list", "teams": s.teams}
+	case r.is("GET", "teams", "*"):
+		for _, team := range s.teams {
+			if team.ID.String() == r.path[1] {
+				return http.StatusOK, team
+			}
+		}
+		return http.StatusNotFound, "not_found"
+
+	case r.is("POST", "events"):
+		event := intercom.Event{}
+		if err := r.decode(&event); err != nil || event.EventName == "" {
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+		if s.findUser(intercom.UserIdentifiers{UserID: event.UserID, Email: event.Email}) == nil {
+			return http.StatusNotFound, "not_found"
+		}
+		s.events = append(s.events, event)
+		return http.StatusAccepted, nil
+
+	case r.is("POST", "messages"):
+		message := intercom.MessageRequest{}
+		if err := r.decode(&message); err != nil {
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+		s.messages = append(s.messages, message)
+		return http.StatusOK, map[string]interface{}{
+			"type":         "admin_message",
+			"id":           s.newID(),
+			"created_at":   s.timestamp(),
+			"message_type": message.MessageType,
+			"subject":      message.Subject,
+			"body":         message.Body,
+			"template":     message.Template,
+			"owner":        message.From,
+		}
+
+	case r.is("POST", "bulk", "*"):
+		return s.postJob(r)
+	case r.is("GET", "jobs", "*"):
+		if job, ok := s.jobs[r.path[1]]; ok {
+			return http.StatusOK, job
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	return http.StatusNotFound, "route_not_found"
+}
+
+func (s *Server) getUsers(r *request) (int, interface{}) {
+	if r.param("user_id") != "" || r.param("email") != "" {
+		if user := s.findUser(intercom.UserIdentifiers{UserID: r.param("user_id"), Email: r.param("email")}); user != nil {
+			return http.StatusOK, user
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	users := []*intercom.User{}
+	for _, user := range s.users {
+		if hasTag(user.Tags, r.param("tag_id")) && hasSegment(user.Segments, r.param("segment_id")) {
+			users = append(users, user)
+		}
+	}
+	start, end, pages := r.page(len(users))
+	return http.StatusOK, map[string]interface{}{"type": "user.list", "pages": pages, "users": users[start:end]}
+}
+
+func (s *Server) postUser(r *request) (int, interface{}) {
+	req := userRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	if req.ID == "" && req.UserID == "" && req.Email == "" {
+		return http.StatusBadRequest, "parameter_not_found"
+	}
+	return http.StatusOK, s.upsertUser(req)
+}
+
+func (s *Server) findUser(params intercom.UserIdentifiers) *intercom.User {
+	for _, user := range s.users {
+		switch {
+		case params.ID != "":
+			if user.ID == params.ID {
+				return user
+			}
+		case params.UserID != "":
+			if user.UserID == params.UserID {
+				return user
+			}
+		case params.Email != "":
+			if user.Email == params.Email {
+				return user
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteUser(params intercom.UserIdentifiers) *intercom.User {
+	user := s.findUser(params)
+	for i := range s.users {
+		if s.users[i] == user {
+			s.users = append(s.users[:i], s.users[i+1:]...)
+			break
+		}
+	}
+	return user
+}
+
+// upsertUser creates a User, or updates the User with the same identifiers.
+func (s *Server) upsertUser(req userRequest) *intercom.User {
+	user := s.findUser(intercom.UserIdentifiers{ID: req.ID, UserID: req.UserID, Email: req.Email})
+	if user == nil {
+		user = &intercom.User{ID: s.newID(), CreatedAt: s.timestamp()}
+		s.users = append(s.users, user)
+	}
+	mergeUser(user, &req.User)
+	user.UpdatedAt = s.timestamp()
+	user.Companies = s.applyUserCompanies(user.Companies, req.Companies)
+	return user
+}
+
+func (s *Server) applyUserCompanies(list *intercom.CompanyList, userCompanies []intercom.UserCompany) *intercom.CompanyList {
+	if list == nil {
+		list = &intercom.CompanyList{}
+	}
+	for _, uc := range userCompanies {
+		company := s.findCompany("", uc.CompanyID, "")
+		if company == nil {
+			company = &intercom.Company{ID: s.newID(), CompanyID: uc.CompanyID, Name: uc.Name, CreatedAt: s.timestamp()}
+			s.companies = append(s.companies, company)
+		}
+		companies := list.Companies[:0]
+		for _, c := range list.Companies {
+			if c.CompanyID != uc.CompanyID {
+				companies = append(companies, c)
+			}
+		}
+		if uc.Remove == nil || !*uc.Remove {
+			companies = append(companies, *company)
+		}
+		list.Companies = companies
+	}
+	return list
+}
+
+// mergeUser copies the writeable fields set on from onto user.
+func mergeUser(user, from *intercom.User) {
+	if from.UserID != "" {
+		user.UserID = from.UserID
+	}
+	if from.Email != "" {
+		user.Email = from.Email
+	}
+	if from.Phone != "" {
+		user.Phone = from.Phone
+	}
+	if from.Name != "" {
+		user.Name = from.Name
+	}
+	if from.SignedUpAt != 0 {
+		user.SignedUpAt = from.SignedUpAt
+	}
+	if from.RemoteCreatedAt != 0 {
+		user.RemoteCreatedAt = from.RemoteCreatedAt
+	}
+	if from.LastRequestAt != 0 {
+		user.LastRequestAt = from.LastRequestAt
+	}
+	if from.LastSeenIP != "" {
+		user.LastSeenIP = from.LastSeenIP
+	}
+	if from.LastSeenUserAgent != "" {
+		user.LastSeenUserAgent = from.LastSeenUserAgent
+	}
+	if from.UnsubscribedFromEmails != nil {
+		user.UnsubscribedFromEmails = from.UnsubscribedFromEmails
+	}
+	if from.NewSession != nil && *from.NewSession {
+		user.SessionCount++
+	}
+	for k, v := range from.CustomAttributes {
+		if user.CustomAttributes == nil {
+			user.CustomAttributes = map[string]interface{}{}
+		}
+		user.CustomAttributes[k] = v
+	}
+}
+
+func (s *Server) getContacts(r *request) (int, interface{}) {
+	if r.param("user_id") != "" {
+		if i := s.contactIndex("", r.param("user_id")); i >= 0 {
+			return http.StatusOK, s.contacts[i]
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	contacts := []*intercom.Contact{}
+	for _, contact := range s.contacts {
+		if (r.param("email") == "" || contact.Email == r.param("email")) &&
+			hasTag(contact.Tags, r.param("tag_id")) && hasSegment(contact.Segments, r.param("segment_id")) {
+			contacts = append(contacts, contact)
+		}
+	}
+	start, end, pages := r.page(len(contacts))
+	return http.StatusOK, map[string]interface{}{"type": "contact.list", "pages": pages, "contacts": contacts[start:end]}
+}
+
+func (s *Server) postContact(r *request) (int, interface{}) {
+	req := userRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	var contact *intercom.Contact
+	if req.ID != "" || req.UserID != "" {
+		i := s.contactIndex(req.ID, req.UserID)
+		if i < 0 {
+			return http.StatusNotFound, "not_found"
+		}
+		contact = s.contacts[i]
+	} else {
+		contact = &intercom.Contact{ID: s.newID(), UserID: s.newID(), CreatedAt: s.timestamp()}
+		s.contacts = append(s.contacts, contact)
+	}
+	user := contactUser(contact)
+	mergeUser(user, &req.User)
+	user.Companies = s.applyUserCompanies(user.Companies, req.Companies)
+	*contact = userContact(user)
+	contact.UpdatedAt = s.timestamp()
+	return http.StatusOK, contact
+}
+
+func (s *Server) convertContact(r *request) (int, interface{}) {
+	req := convertRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	i := s.contactIndex(req.Contact.ID, req.Contact.UserID)
+	if i < 0 {
+		return http.StatusNotFound, "not_found"
+	}
+	contact := s.contacts[i]
+	s.contacts = append(s.contacts[:i], s.contacts[i+1:]...)
+	user := s.upsertUser(req.User)
+	from := contactUser(contact)
+	from.UserID = ""
+	mergeUser(user, from)
+	return http.StatusOK, user
+}
+
+// unifiedContacts are the stored Users and Contacts as the unified Contacts searched by API version 2.0.
+func (s *Server) unifiedContacts() []intercom.UnifiedContact {
+	contacts := make([]intercom.UnifiedContact, 0, len(s.users)+len(s.contacts))
+	for _, user := range s.users {
+		contacts = append(contacts, user.UnifiedContact())
+	}
+	for _, contact := range s.contacts {
+		contacts = append(contacts, contact.UnifiedContact())
+	}
+	return contacts
+}
+
+func (s *Server) contactIndex(id, userID string) int {
+	for i, contact := range s.contacts {
+		if (id != "" && contact.ID == id) || (id == "" && userID != "" && contact.UserID == userID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func contactUser(c *intercom.Contact) *intercom.User {
+	return &intercom.User{
+		ID: c.ID, Email: c.Email, Phone: c.Phone, UserID: c.UserID, Name: c.Name, Avatar: c.Avatar,
+		LocationData: c.LocationData, LastRequestAt: c.LastRequestAt, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+		SessionCount: c.SessionCount, LastSeenIP: c.LastSeenIP, SocialProfiles: c.SocialProfiles,
+		UnsubscribedFromEmails: c.UnsubscribedFromEmails, UserAgentData: c.UserAgentData, Tags: c.Tags,
+		Segments: c.Segments, Companies: c.Companies, CustomAttributes: c.CustomAttributes,
+	}
+}
+
+func userContact(u *intercom.User) intercom.Contact {
+	return intercom.Contact{
+		ID: u.ID, Email: u.Email, Phone: u.Phone, UserID: u.UserID, Name: u.Name, Avatar: u.Avatar,
+		LocationData: u.LocationData, LastRequestAt: u.LastRequestAt, CreatedAt: u.CreatedAt, UpdatedAt: u.UpdatedAt,
+		SessionCount: u.SessionCount, LastSeenIP: u.LastSeenIP, SocialProfiles: u.SocialProfiles,
+		UnsubscribedFromEmails: u.UnsubscribedFromEmails, UserAgentData: u.UserAgentData, Tags: u.Tags,
+		Segments: u.Segments, Companies: u.Companies, CustomAttributes: u.CustomAttributes,
+	}
+}
+
+func (s *Server) getCompanies(r *request) (int, interface{}) {
+	if r.param("type") == "user" {
+		if company := s.findCompany("", r.param("company_id"), ""); company != nil {
+			return s.companyUsers(r, company)
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	if r.param("company_id") != "" || r.param("name") != "" {
+		if company := s.findCompany("", r.param("company_id"), r.param("name")); company != nil {
+			return http.StatusOK, company
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	companies := []*intercom.Company{}
+	for _, company := range s.companies {
+		if hasTag(company.Tags, r.param("tag_id")) && hasSegment(company.Segments, r.param("segment_id")) {
+			companies = append(companies, company)
+		}
+	}
+	start, end, pages := r.page(len(companies))
+	return http.StatusOK, map[string]interface{}{"type": "company.list", "pages": pages, "companies": companies[start:end]}
+}
+
+func (s *Server) companyUsers(r *request, company *intercom.Company) (int, interface{}) {
+	users := []*intercom.User{}
+	for _, user := range s.users {
+		if user.Companies == nil {
+			continue
+		}
+		for _, c := range user.Companies.Companies {
+			if c.ID == company.ID {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	start, end, pages := r.page(len(users))
+	return http.StatusOK, map[string]interface{}{"type": "user.list", "pages": pages, "users": users[start:end]}
+}
+
+func (s *Server) postCompany(r *request) (int, interface{}) {
+	req := companyRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	if req.ID == "" && req.CompanyID == "" {
+		return http.StatusBadRequest, "parameter_not_found"
+	}
+	company := s.findCompany(req.ID, req.CompanyID, "")
+	if company == nil {
+		company = &intercom.Company{ID: s.newID(), CompanyID: req.CompanyID, CreatedAt: s.timestamp()}
+		s.companies = append(s.companies, company)
+	}
+	if req.Name != "" {
+		company.Name = req.Name
+	}
+	if req.RemoteCreatedAt != 0 {
+		company.RemoteCreatedAt = req.RemoteCreatedAt
+	}
+	if req.MonthlySpend != 0 {
+		company.MonthlySpend = req.MonthlySpend
+	}
+	if req.Industry != "" {
+		company.Industry = req.Industry
+	}
+	if req.Size != 0 {
+		company.Size = req.Size
+	}
+	if req.Plan != "" {
+		company.Plan = &intercom.Plan{ID: req.Plan, Name: req.Plan}
+	}
+	for k, v := range req.CustomAttributes {
+		if company.CustomAttributes == nil {
+			company.CustomAttributes = map[string]interface{}{}
+		}
+		company.CustomAttributes[k] = v
+	}
+	company.UpdatedAt = s.timestamp()
+	return http.StatusOK, company
+}
+
+func (s *Server) findCompany(id, companyID, name string) *intercom.Company {
+	for _, company := range s.companies {
+		if (id != "" && company.ID == id) ||
+			(id == "" && companyID != "" && company.CompanyID == companyID) ||
+			(id == "" && companyID == "" && name != "" && company.Name == name) {
+			return company
+		}
+	}
+	return nil
+}
+
+func (s *Server) getConversations(r *request) (int, interface{}) {
+	convos := []*intercom.Conversation{}
+	for _, convo := range s.conversations {
+		switch r.param("type") {
+		case "admin":
+			if convo.Assignee.ID.String() != r.param("admin_id") {
+				continue
+			}
+			if open := r.param("open"); open != "" && (open == "true") != convo.Open {
+				continue
+			}
+		case "user":
+			user := convo.User
+			if r.param("intercom_user_id") != "" && user.ID != r.param("intercom_user_id") ||
+				r.param("user_id") != "" && user.UserID != r.param("user_id") ||
+				r.param("email") != "" && user.Email != r.param("email") {
+				continue
+			}
+			if r.param("unread") == "true" && convo.Read {
+				continue
+			}
+		}
+		convos = append(convos, convo)
+	}
+	start, end, pages := r.page(len(convos))
+	return http.StatusOK, map[string]interface{}{"type": "conversation.list", "pages": pages, "conversations": convos[start:end]}
+}
+
+func (s *Server) findConversation(id string) *intercom.Conversation {
+	for _, convo := range s.conversations {
+		if convo.ID == id {
+			return convo
+		}
+	}
+	return nil
+}
+
+func (s *Server) replyToConversation(r *request) (int, interface{}) {
+	convo := s.findConversation(r.path[1])
+	if convo == nil {
+		return http.StatusNotFound, "not_found"
+	}
+	reply := intercom.Reply{}
+	if err := r.decode(&reply); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	part := intercom.ConversationPart{
+		ID:        s.newID(),
+		PartType:  reply.ReplyType,
+		Body:      reply.Body,
+		CreatedAt: s.timestamp(),
+		UpdatedAt: s.timestamp(),
+		Author:    intercom.MessageAddress{Type: reply.Type, ID: reply.AdminID + reply.IntercomID, UserID: reply.UserID, Email: reply.Email},
+	}
+	switch reply.ReplyType {
+	case "comment", "note":
+		if reply.Type == "user" {
+			convo.Open = true
+		}
+	case "assignment":
+		part.Author.Type = "admin"
+		switch {
+		case reply.Type == "team" && reply.AssigneeID == "0":
+			convo.TeamAssigneeID = ""
+		case reply.Type == "team":
+			convo.TeamAssigneeID = reply.AssigneeID
+		case reply.AssigneeID == "0":
+			convo.Assignee = intercom.Admin{Type: "nobody_admin"}
+			part.AssignedTo = convo.Assignee
+		default:
+			convo.Assignee = s.findAdmin(reply.AssigneeID)
+			part.AssignedTo = convo.Assignee
+		}
+	case "open":
+		convo.Open, convo.State, convo.SnoozedUntil = true, "open", 0
+	case "close":
+		convo.Open, convo.State, convo.SnoozedUntil = false, "closed", 0
+	case "snoozed":
+		convo.Open, convo.State, convo.SnoozedUntil = true, "snoozed", reply.SnoozedUntil
+	case "priority", "not_priority":
+		convo.Priority = reply.ReplyType
+	default:
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	convo.UpdatedAt = s.timestamp()
+	convo.ConversationParts.Parts = append(convo.ConversationParts.Parts, part)
+	return http.StatusOK, convo
+}
+
+func (s *Server) createConversation(r *request) (int, interface{}) {
+	req := struct {
+		From intercom.MessageAddress `json:"from"`
+		Body string                  `json:"body"`
+	}{}
+	if err := r.decode(&req); err != nil || req.Body == "" {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	author := req.From
+	switch author.Type {
+	case "user":
+		user := s.findUser(intercom.UserIdentifiers{ID: author.ID, UserID: author.UserID, Email: author.Email})
+		if user == nil {
+			return http.StatusNotFound, "not_found"
+		}
+		author.ID = user.ID
+	case "contact", "lead":
+		i := s.contactIndex(author.ID, author.UserID)
+		if i < 0 {
+			return http.StatusNotFound, "not_found"
+		}
+		author.ID = s.contacts[i].ID
+	default:
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	message := intercom.ConversationMessage{ID: s.newID(), Body: req.Body, Author: author}
+	convo := &intercom.Conversation{
+		ID:                  s.newID(),
+		CreatedAt:           s.timestamp(),
+		UpdatedAt:           s.timestamp(),
+		User:                intercom.User{ID: author.ID},
+		Open:                true,
+		State:               "open",
+		ConversationMessage: message,
+	}
+	s.conversations = append(s.conversations, convo)
+	return http.StatusOK, map[string]interface{}{
+		"type":            "user_message",
+		"id":              message.ID,
+		"created_at":      convo.CreatedAt,
+		"body":            message.Body,
+		"message_type":    "inapp",
+		"conversation_id": convo.ID,
+	}
+}
+
+func (s *Server) redact(r *request) (int, interface{}) {
+	redaction := intercom.Redaction{}
+	if err := r.decode(&redaction); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	convo := s.findConversation(redaction.ConversationID)
+	if convo == nil {
+		return http.StatusNotFound, "not_found"
+	}
+	switch redaction.Type {
+	case "source":
+		if convo.ConversationMessage.ID != redaction.SourceID {
+			return http.StatusNotFound, "not_found"
+		}
+		convo.ConversationMessage.Body = ""
+		convo.ConversationMessage.Attachments = nil
+		return http.StatusOK, convo
+	case "conversation_part":
+		for i := range convo.ConversationParts.Parts {
+			if part := &convo.ConversationParts.Parts[i]; part.ID == redaction.ConversationPartID {
+				part.Body, part.Attachments, part.Redacted = "", nil, true
+				return http.StatusOK, convo
+			}
+		}
+		return http.StatusNotFound, "not_found"
+	}
+	return http.StatusBadRequest, "parameter_invalid"
+}
+
+// saveDataAttribute creates a DataAttribute, or updates the given one.
+func (s *Server) saveDataAttribute(r *request, attribute *intercom.DataAttribute) (int, interface{}) {
+	request := struct {
+		Name              string `json:"name"`
+		Model             string `json:"model"`
+		DataType          string `json:"data_type"`
+		Description       string `json:"description"`
+		MessengerWritable bool   `json:"messenger_writable"`
+		Archived          bool   `json:"archived"`
+		Options           []struct {
+			Value string `json:"value"`
+		} `json:"options"`
+	}{}
+	if err := r.decode(&request); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	if attribute == nil {
+		if request.Name == "" || request.Model == "" || request.DataType == "" {
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+		attribute = s.addDataAttribute(intercom.DataAttribute{Name: request.Name, Model: request.Model, DataType: request.DataType, CreatedAt: s.timestamp()})
+	}
+	attribute.Description, attribute.MessengerWritable, attribute.Archived = request.Description, request.MessengerWritable, request.Archived
+	attribute.Options = nil
+	for _, option := range request.Options {
+		attribute.Options = append(attribute.Options, option.Value)
+	}
+	attribute.UpdatedAt = s.timestamp()
+	return http.StatusOK, attribute
+}
+
+// findStoredAdmin finds a stored Admin by ID, for updating in place.
+func (s *Server) findStoredAdmin(id string) *intercom.Admin {
+	for i := range s.admins {
+		if s.admins[i].ID.String() == id {
+			return &s.admins[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) findAdmin(id string) intercom.Admin {
+	for _, admin := range s.admins {
+		if admin.ID.String() == id {
+			return admin
+		}
+	}
+	return intercom.Admin{ID: json.Number(id), Type: "admin"}
+}
+
+func (s *Server) postTag(r *request) (int, interface{}) {
+	req := taggingRequest{}
+	if err := r.decode(&req); err != nil || req.Name == "" {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	tag := s.findTag(req.ID, req.Name)
+	if tag == nil {
+		tag = &intercom.Tag{ID: s.newID()}
+		s.tags = append(s.tags, tag)
+	}
+	tag.Name = req.Name
+	for _, tagging := range req.Users {
+		if user := s.findUser(intercom.UserIdentifiers{ID: tagging.ID, UserID: tagging.UserID, Email: tagging.Email}); user != nil {
+			user.Tags = applyTag(user.Tags, *tag, tagging.Untag)
+		}
+	}
+	for _, tagging := range req.Companies {
+		if company := s.findCompany(tagging.ID, tagging.CompanyID, ""); company != nil {
+			company.Tags = applyTag(company.Tags, *tag, tagging.Untag)
+		}
+	}
+	return http.StatusOK, tag
+}
+
+func (s *Server) findTag(id, name string) *intercom.Tag {
+	for _, tag := range s.tags {
+		if (id != "" && tag.ID == id) || (id == "" && tag.Name == name) {
+			return tag
+		}
+	}
+	return nil
+}
+
+func applyTag(list *intercom.TagList, tag intercom.Tag, untag *bool) *intercom.TagList {
+	if list == nil {
+		list = &intercom.TagList{}
+	}
+	tags := list.Tags[:0]
+	for _, t := range list.Tags {
+		if t.ID != tag.ID {
+			tags = append(tags, t)
+		}
+	}
+	if untag == nil || !*untag {
+		tags = append(tags, tag)
+	}
+	list.Tags = tags
+	return list
+}
+
+func hasTag(list *intercom.TagList, id string) bool {
+	if id == "" {
+		return true
+	}
+	if list != nil {
+		for _, tag := range list.Tags {
+			if tag.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasSegment(list *intercom.SegmentList, id string) bool {
+	if id == "" {
+		return true
+	}
+	if list != nil {
+		for _, segment := range list.Segments {
+			if segment.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// postJob creates or appends to a bulk Job. Items are applied immediately,
+// so Jobs are always completed.
+func (s *Server) postJob(r *request) (int, interface{}) {
+	bulkType := r.path[1]
+	if bulkType != "users" && bulkType != "events" {
+		return http.StatusNotFound, "route_not_found"
+	}
+	req := jobRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	var job *intercom.JobResponse
+	if req.JobData != nil && req.JobData.ID != "" {
+		if job = s.jobs[req.JobData.ID]; job == nil {
+			return http.StatusNotFound, "not_found"
+		}
+	} else {
+		id := "job_" + s.newID()
+		job = &intercom.JobResponse{
+			ID:        id,
+			AppID:     "intercomtest",
+			CreatedAt: s.timestamp(),
+			Name:      "api_bulk_job",
+			Links:     map[string]string{"self": s.URL + "/jobs/" + id},
+		}
+		s.jobs[id] = job
+	}
+	for _, item := range req.Items {
+		switch {
+		case item.DataType == "user" && item.Method == "post":
+			user := userRequest{}
+			if json.Unmarshal(item.Data, &user) == nil {
+				s.upsertUser(user)
+			}
+		case item.DataType == "user" && item.Method == "delete":
+			user := intercom.User{}
+			if json.Unmarshal(item.Data, &user) == nil {
+				s.deleteUser(intercom.UserIdentifiers{ID: user.ID, UserID: user.UserID, Email: user.Email})
+			}
+		case item.DataType == "event" && item.Method == "post":
+			event := intercom.Event{}
+			if json.Unmarshal(item.Data, &event) == nil {
+				s.events = append(s.events, event)
+			}
+		default:
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+	}
+	job.State = intercom.COMPLETED.String()
+	job.UpdatedAt = s.timestamp()
+	job.CompletedAt = s.timestamp()
+	return http.StatusAccepted, job
+}
+
+func (s *Server) counts(r *request) (int, interface{}) {
+	count := func(n int) map[string]int { return map[string]int{"count": n} }
+	switch r.param("type") + "." + r.param("count") {
+	case ".":
+		return http.StatusOK, map[string]interface{}{
+			"type":    "count.hash",
+			"company": count(len(s.companies)),
+			"lead":    count(len(s.contacts)),
+			"segment": count(len(s.segments)),
+			"tag":     count(len(s.tags)),
+			"user":    count(len(s.users)),
+		}
+	case "conversation.":
+		counts := map[string]int{"open": 0, "closed": 0, "assigned": 0, "unassigned": 0}
+		for _, convo := range s.conversations {
+			if convo.Open {
+				counts["open"]++
+			} else {
+				counts["closed"]++
+			}
+			if convo.Assignee.ID != "" {
+				counts["assigned"]++
+			} else {
+				counts["unassigned"]++
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"type": "count", "conversation": counts}
+	case "conversation.admin":
+		admins := []map[string]interface{}{}
+		for _, admin := range s.admins {
+			open, closed := 0, 0
+			for _, convo := range s.conversations {
+				if convo.Assignee.ID == admin.ID && convo.Open {
+					open++
+				} else if convo.Assignee.ID == admin.ID {
+					closed++
+				}
+			}
+			admins = append(admins, map[string]interface{}{"id": admin.ID, "name": admin.Name, "email": admin.Email, "open": open, "closed": closed})
+		}
+		return http.StatusOK, map[string]interface{}{"type": "count", "conversation": map[string]interface{}{"admin": admins}}
+	case "user.tag", "user.segment", "company.tag", "company.segment":
+		counts := []map[string]int{}
+		for _, name := range s.countNames(r.param("count")) {
+			counts = append(counts, map[string]int{name: s.countMembers(r.param("type"), r.param("count"), name)})
+		}
+		return http.StatusOK, map[string]interface{}{"type": "count", r.param("type"): map[string]interface{}{r.param("count"): counts}}
+	case "company.user":
+		counts := []map[string]interface{}{}
+		for _, company := range s.companies {
+			n := s.countMembers("company", "user", company.Name)
+			counts = append(counts, map[string]interface{}{company.Name: map[string]interface{}{"count": n, "remote_company_id": company.CompanyID}})
+		}
+		return http.StatusOK, map[string]interface{}{"type": "count", "company": map[string]interface{}{"user": counts}}
+	}
+	return http.StatusBadRequest, "parameter_invalid"
+}
+
+// countNames lists the names of the Tags, Segments or Companies that members are counted for.
+func (s *Server) countNames(of string) []string {
+	names := []string{}
+	switch of {
+	case "tag":
+		for _, tag := range s.tags {
+			names = append(names, tag.Name)
+		}
+	case "segment":
+		for _, segment := range s.segments {
+			names = append(names, segment.Name)
+		}
+	}
+	return names
+}
+
+// countMembers counts the Users or Companies in the named Tag or Segment, or the Users in the named Company.
+func (s *Server) countMembers(memberType, of, name string) int {
+	n := 0
+	has := func(tags *intercom.TagList, segments *intercom.SegmentList) bool {
+		if of == "tag" && tags != nil {
+			for _, tag := range tags.Tags {
+				if tag.Name == name {
+					return true
+				}
+			}
+		}
+		if of == "segment" && segments != nil {
+			for _, segment := range segments.Segments {
+				if segment.Name == name {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	switch {
+	case memberType == "user":
+		for _, user := range s.users {
+			if has(user.Tags, user.Segments) {
+				n++
+			}
+		}
+	case of == "user":
+		for _, user := range s.users {
+			if user.Companies == nil {
+				continue
+			}
+			for _, company := range user.Companies.Companies {
+				if stored := s.findCompany(company.ID, "", ""); company.Name == name || (stored != nil && stored.Name == name) {
+					n++
+					break
+				}
+			}
+		}
+	default:
+		for _, company := range s.companies {
+			if has(company.Tags, company.Segments) {
+				n++
+			}
+		}
+	}
+	return n
+}
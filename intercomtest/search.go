@@ -0,0 +1,151 @@
+package intercomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	intercom "github.com/opensimsim/intercom-go"
+)
+
+// search filters items by the query of a SearchRequest body, matching fields of their
+// JSON, and returns the requested page of results with a cursor for the next.
+func search[T any](r *request, listType, key string, items []T) (int, interface{}) {
+	req := intercom.SearchRequest{}
+	if err := r.decode(&req); err != nil {
+		return http.StatusBadRequest, "parameter_invalid"
+	}
+	matched := []T{}
+	for _, item := range items {
+		fields := map[string]interface{}{}
+		data, _ := json.Marshal(item)
+		json.Unmarshal(data, &fields)
+		ok, err := matches(req.Query, fields)
+		if err != nil {
+			return http.StatusBadRequest, "parameter_invalid"
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+
+	perPage, start := 20, 0
+	if req.Pagination != nil {
+		if req.Pagination.PerPage > 0 {
+			perPage = int(req.Pagination.PerPage)
+		}
+		if req.Pagination.StartingAfter != "" {
+			start, _ = strconv.Atoi(req.Pagination.StartingAfter)
+		}
+	}
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + perPage
+	if end > len(matched) {
+		end = len(matched)
+	}
+	totalPages := (len(matched) + perPage - 1) / perPage
+	pages := map[string]interface{}{"type": "pages", "page": start/perPage + 1, "per_page": perPage, "total_pages": totalPages}
+	if end < len(matched) {
+		pages["next"] = map[string]interface{}{"page": end/perPage + 1, "starting_after": strconv.Itoa(end)}
+	}
+	return http.StatusOK, map[string]interface{}{"type": listType, "pages": pages, "total_count": len(matched), key: matched[start:end]}
+}
+
+// matches evaluates a query against the JSON fields of an item. Sorting is not supported.
+func matches(query intercom.SearchQuery, fields map[string]interface{}) (bool, error) {
+	switch query.Operator {
+	case "AND", "OR":
+		queries := []intercom.SearchQuery{}
+		data, _ := json.Marshal(query.Value)
+		if err := json.Unmarshal(data, &queries); err != nil {
+			return false, err
+		}
+		for _, q := range queries {
+			ok, err := matches(q, fields)
+			if err != nil {
+				return false, err
+			}
+			if ok == (query.Operator == "OR") {
+				return ok, nil
+			}
+		}
+		return query.Operator == "AND", nil
+	}
+
+	value := lookup(fields, query.Field)
+	actual, expected := fmt.Sprint(value), fmt.Sprint(query.Value)
+	switch query.Operator {
+	case "=":
+		return equal(value, query.Value), nil
+	case "!=":
+		return !equal(value, query.Value), nil
+	case "IN", "NIN":
+		values, _ := query.Value.([]interface{})
+		for _, v := range values {
+			if equal(value, v) {
+				return query.Operator == "IN", nil
+			}
+		}
+		return query.Operator == "NIN", nil
+	case ">", "<":
+		a, aok := value.(float64)
+		e, eok := query.Value.(float64)
+		if !aok || !eok {
+			return false, nil
+		}
+		return (query.Operator == ">" && a > e) || (query.Operator == "<" && a < e), nil
+	case "~":
+		return strings.Contains(actual, expected), nil
+	case "!~":
+		return !strings.Contains(actual, expected), nil
+	case "^":
+		return strings.HasPrefix(actual, expected), nil
+	case "$":
+		return strings.HasSuffix(actual, expected), nil
+	}
+	return false, fmt.Errorf("unknown operator %s", query.Operator)
+}
+
+// lookup finds a field by its dotted path, such as "statistics.last_admin_reply_at".
+// A field of a list of objects, such as "tags.id", is the list of the field's values.
+func lookup(fields map[string]interface{}, path string) interface{} {
+	var value interface{} = fields
+	for _, name := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[name]
+		case []interface{}:
+			values := []interface{}{}
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					values = append(values, m[name])
+				}
+			}
+			value = values
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+// equal compares JSON values as strings, so that IDs match whether given as numbers or strings.
+// A list is equal to a value it contains.
+func equal(value, expected interface{}) bool {
+	if values, ok := value.([]interface{}); ok {
+		for _, v := range values {
+			if equal(v, expected) {
+				return true
+			}
+		}
+		return false
+	}
+	if value == nil || expected == nil {
+		return value == expected
+	}
+	return fmt.Sprint(value) == fmt.Sprint(expected)
+}
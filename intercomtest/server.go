@@ -0,0 +1,439 @@
+// Package intercomtest provides an in-memory fake of the Intercom API for tests.
+//
+// A Server implements the endpoints used by the intercom package, keeping
+// Users, Contacts, Companies, Conversations, Tags, Segments, Events, Messages
+// and Jobs in memory:
+//
+//  server := intercomtest.NewServer()
+//  defer server.Close()
+//  ic := server.Client()
+//  ic.Users.Save(ctx, &intercom.User{UserID: "27"})
+//
+// Errors and rate limiting can be injected with Fail and SetRateLimit.
+package intercomtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	intercom "github.com/opensimsim/intercom-go"
+)
+
+// defaultPerPage is used when a list request does not specify per_page.
+const defaultPerPage = 50
+
+// A Server is a fake Intercom API, listening on a local address.
+// Point an intercom.Client at it with the intercom.BaseURI option, or use Client.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	nextID        int64
+	admins        []intercom.Admin
+	teams         []intercom.Team
+	attributes    []*intercom.DataAttribute
+	users         []*intercom.User
+	contacts      []*intercom.Contact
+	companies     []*intercom.Company
+	conversations []*intercom.Conversation
+	tags          []*intercom.Tag
+	segments      []*intercom.Segment
+	events        []intercom.Event
+	messages      []intercom.MessageRequest
+	jobs          map[string]*intercom.JobResponse
+	requests      []Request
+	failures      []*failure
+	rateLimit     *rateLimit
+	now           func() time.Time
+}
+
+// A Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+type failure struct {
+	method     string
+	path       string
+	remaining  int
+	statusCode int
+	code       string
+}
+
+type rateLimit struct {
+	limit     int
+	remaining int
+	window    time.Duration
+	reset     time.Time
+}
+
+// NewServer starts and returns a new Server. Close should be called when finished.
+func NewServer() *Server {
+	s := &Server{jobs: map[string]*intercom.JobResponse{}, now: time.Now}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an intercom.Client configured to use the Server.
+func (s *Server) Client() *intercom.Client {
+	ic := intercom.NewClient("intercomtest", "intercomtest")
+	ic.Option(intercom.BaseURI(s.URL))
+	return ic
+}
+
+// Fail makes the next n requests with the given method and path fail with
+// the HTTP status code and Intercom error code, such as 404 and "not_found".
+// A method or path of "*" matches any.
+func (s *Server) Fail(method, path string, n int, statusCode int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{method: method, path: path, remaining: n, statusCode: statusCode, code: code})
+}
+
+// SetRateLimit applies a rate limit of limit requests per window, reported in
+// X-RateLimit-* headers. Requests over the limit fail with a 429 until the window resets.
+func (s *Server) SetRateLimit(limit int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimit = &rateLimit{limit: limit, remaining: limit, window: window, reset: s.now().Add(window)}
+}
+
+// Requests returns every request received by the Server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Access Token Invalid")
+		return
+	}
+	if !s.applyRateLimit(w) {
+		writeError(w, http.StatusTooManyRequests, "rate_limit_exceeded", "Exceeded rate limit")
+		return
+	}
+	if f := s.matchFailure(r); f != nil {
+		writeError(w, f.statusCode, f.code, http.StatusText(f.statusCode))
+		return
+	}
+
+	req := &request{Request: r, body: body, query: r.URL.Query(), path: strings.Split(strings.Trim(r.URL.Path, "/"), "/")}
+	status, response := s.route(req)
+	if status >= 400 {
+		code, _ := response.(string)
+		writeError(w, status, code, http.StatusText(status))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if response != nil {
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (s *Server) applyRateLimit(w http.ResponseWriter) bool {
+	if s.rateLimit == nil {
+		return true
+	}
+	rl := s.rateLimit
+	if now := s.now(); !now.Before(rl.reset) {
+		rl.remaining = rl.limit
+		rl.reset = now.Add(rl.window)
+	}
+	allowed := rl.remaining > 0
+	if allowed {
+		rl.remaining--
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rl.limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(rl.remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(rl.reset.Unix(), 10))
+	return allowed
+}
+
+func (s *Server) matchFailure(r *http.Request) *failure {
+	for i, f := range s.failures {
+		if (f.method == "*" || f.method == r.Method) && (f.path == "*" || f.path == r.URL.Path) {
+			f.remaining--
+			if f.remaining <= 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+			return f
+		}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":       "error.list",
+		"request_id": "intercomtest",
+		"errors":     []map[string]string{{"code": code, "message": message}},
+	})
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%024x", s.nextID)
+}
+
+func (s *Server) timestamp() int64 {
+	return s.now().Unix()
+}
+
+// request is an incoming request split into its path segments.
+type request struct {
+	*http.Request
+	body  []byte
+	query map[string][]string
+	path  []string
+}
+
+func (r *request) param(name string) string {
+	if values := r.query[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (r *request) decode(v interface{}) error {
+	return json.Unmarshal(r.body, v)
+}
+
+// is checks the method and path, with "*" matching any single path segment.
+func (r *request) is(method string, path ...string) bool {
+	if r.Method != method || len(r.path) != len(path) {
+		return false
+	}
+	for i := range path {
+		if path[i] != "*" && path[i] != r.path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// page returns the bounds of the requested page of n items, and the pages object to return.
+func (r *request) page(n int) (int, int, map[string]interface{}) {
+	page, _ := strconv.Atoi(r.param("page"))
+	perPage, _ := strconv.Atoi(r.param("per_page"))
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	totalPages := (n + perPage - 1) / perPage
+	start, end := (page-1)*perPage, page*perPage
+	if start > n {
+		start = n
+	}
+	if end > n {
+		end = n
+	}
+	return start, end, map[string]interface{}{"type": "pages", "page": page, "per_page": perPage, "total_pages": totalPages}
+}
+
+// scroll returns the bounds of the next page of n items, and the scroll_param to return.
+func (r *request) scroll(n int) (int, int, string) {
+	start, _ := strconv.Atoi(strings.TrimPrefix(r.param("scroll_param"), "scroll-"))
+	if start > n {
+		start = n
+	}
+	end := start + defaultPerPage
+	if end > n {
+		end = n
+	}
+	return start, end, fmt.Sprintf("scroll-%d", end)
+}
+
+// AddUser stores a User, assigning an ID if it has none, and returns the stored User.
+func (s *Server) AddUser(user intercom.User) intercom.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user.ID == "" {
+		user.ID = s.newID()
+	}
+	s.users = append(s.users, &user)
+	return user
+}
+
+// AddContact stores a Contact, assigning an ID and UserID if it has none, and returns the stored Contact.
+func (s *Server) AddContact(contact intercom.Contact) intercom.Contact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if contact.ID == "" {
+		contact.ID = s.newID()
+	}
+	if contact.UserID == "" {
+		contact.UserID = s.newID()
+	}
+	s.contacts = append(s.contacts, &contact)
+	return contact
+}
+
+// AddCompany stores a Company, assigning an ID if it has none, and returns the stored Company.
+func (s *Server) AddCompany(company intercom.Company) intercom.Company {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if company.ID == "" {
+		company.ID = s.newID()
+	}
+	s.companies = append(s.companies, &company)
+	return company
+}
+
+// AddConversation stores a Conversation, assigning an ID if it has none, and returns the stored Conversation.
+func (s *Server) AddConversation(convo intercom.Conversation) intercom.Conversation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if convo.ID == "" {
+		convo.ID = s.newID()
+	}
+	s.conversations = append(s.conversations, &convo)
+	return convo
+}
+
+// AddTag stores a Tag, assigning an ID if it has none, and returns the stored Tag.
+func (s *Server) AddTag(tag intercom.Tag) intercom.Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tag.ID == "" {
+		tag.ID = s.newID()
+	}
+	s.tags = append(s.tags, &tag)
+	return tag
+}
+
+// AddSegment stores a Segment, assigning an ID if it has none, and returns the stored Segment.
+func (s *Server) AddSegment(segment intercom.Segment) intercom.Segment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if segment.ID == "" {
+		segment.ID = s.newID()
+	}
+	s.segments = append(s.segments, &segment)
+	return segment
+}
+
+// AddAdmin stores an Admin, assigning an ID if it has none, and returns the stored Admin.
+func (s *Server) AddAdmin(admin intercom.Admin) intercom.Admin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if admin.ID == "" {
+		admin.ID = json.Number(strconv.Itoa(len(s.admins) + 1))
+	}
+	if admin.Type == "" {
+		admin.Type = "admin"
+	}
+	s.admins = append(s.admins, admin)
+	return admin
+}
+
+// AddDataAttribute stores a custom DataAttribute, assigning an ID if it has none, and returns the stored DataAttribute.
+func (s *Server) AddDataAttribute(attribute intercom.DataAttribute) intercom.DataAttribute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addDataAttribute(attribute)
+}
+
+func (s *Server) addDataAttribute(attribute intercom.DataAttribute) *intercom.DataAttribute {
+	if attribute.ID == "" {
+		attribute.ID = json.Number(strconv.Itoa(len(s.attributes) + 1))
+	}
+	attribute.FullName = "custom_attributes." + attribute.Name
+	attribute.APIWritable, attribute.Custom = true, true
+	s.attributes = append(s.attributes, &attribute)
+	return &attribute
+}
+
+// AddTeam stores a Team, assigning an ID if it has none, and returns the stored Team.
+func (s *Server) AddTeam(team intercom.Team) intercom.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if team.ID == "" {
+		team.ID = json.Number(strconv.Itoa(len(s.teams) + 1))
+	}
+	team.Type = "team"
+	if team.AdminIDs == nil {
+		team.AdminIDs = []json.Number{}
+	}
+	s.teams = append(s.teams, team)
+	return team
+}
+
+// Users returns the stored Users.
+func (s *Server) Users() []intercom.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]intercom.User, len(s.users))
+	for i, user := range s.users {
+		users[i] = *user
+	}
+	return users
+}
+
+// Contacts returns the stored Contacts.
+func (s *Server) Contacts() []intercom.Contact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	contacts := make([]intercom.Contact, len(s.contacts))
+	for i, contact := range s.contacts {
+		contacts[i] = *contact
+	}
+	return contacts
+}
+
+// Companies returns the stored Companies.
+func (s *Server) Companies() []intercom.Company {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	companies := make([]intercom.Company, len(s.companies))
+	for i, company := range s.companies {
+		companies[i] = *company
+	}
+	return companies
+}
+
+// Conversations returns the stored Conversations.
+func (s *Server) Conversations() []intercom.Conversation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	convos := make([]intercom.Conversation, len(s.conversations))
+	for i, convo := range s.conversations {
+		convos[i] = *convo
+	}
+	return convos
+}
+
+// Events returns the Events received, individually or in bulk.
+func (s *Server) Events() []intercom.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]intercom.Event{}, s.events...)
+}
+
+// Messages returns the Messages received.
+func (s *Server) Messages() []intercom.MessageRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]intercom.MessageRequest{}, s.messages...)
+}
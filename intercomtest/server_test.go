@@ -0,0 +1,401 @@
+package intercomtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	intercom "github.com/opensimsim/intercom-go"
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestUsers(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+
+	user, err := ic.Users.Save(ctx, &intercom.User{UserID: "27", Email: "27@example.io", CustomAttributes: map[string]interface{}{"is_cool": true}})
+	if err != nil {
+		t.Fatalf("Error saving User: %v", err)
+	}
+	if user.ID == "" || user.UserID != "27" {
+		t.Errorf("Saved User was %+v", user)
+	}
+	updated, _ := ic.Users.Save(ctx, &intercom.User{UserID: "27", Name: "Joe"})
+	if updated.ID != user.ID || updated.Name != "Joe" || updated.Email != "27@example.io" {
+		t.Errorf("Updated User was %+v", updated)
+	}
+	found, err := ic.Users.FindByEmail(ctx, "27@example.io")
+	if err != nil || found.ID != user.ID || found.CustomAttributes["is_cool"] != true {
+		t.Errorf("Found User %+v with error %v", found, err)
+	}
+	if _, err := ic.Users.FindByUserID(ctx, "28"); !errors.Is(err, intercom.ErrNotFound) {
+		t.Errorf("Error was %v, expected %v", err, intercom.ErrNotFound)
+	}
+	if _, err := ic.Users.Delete(ctx, user.ID); err != nil {
+		t.Errorf("Error deleting User: %v", err)
+	}
+	if len(server.Users()) != 0 {
+		t.Errorf("Users were %+v after delete", server.Users())
+	}
+}
+
+func TestListAndScroll(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	for i := 0; i < 120; i++ {
+		server.AddUser(intercom.User{})
+	}
+
+	list, err := ic.Users.List(ctx, intercom.PageParams{Page: 2, PerPage: 50})
+	if err != nil || len(list.Users) != 50 || list.Pages.Page != 2 || list.Pages.TotalPages != 3 {
+		t.Errorf("List returned %d users, pages %+v, error %v", len(list.Users), list.Pages, err)
+	}
+	n := 0
+	for iter := ic.Users.IterateScroll(ctx); iter.Next(); n++ {
+	}
+	if n != 120 {
+		t.Errorf("Scrolled %d users, expected 120", n)
+	}
+}
+
+func TestCompanyUsers(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+
+	company, _ := ic.Companies.Save(ctx, &intercom.Company{CompanyID: "5", Name: "Acme", Plan: &intercom.Plan{Name: "pro"}})
+	if company.Plan == nil || company.Plan.Name != "pro" {
+		t.Errorf("Saved Company was %+v", company)
+	}
+	ic.Users.Save(ctx, &intercom.User{UserID: "27", Companies: &intercom.CompanyList{Companies: []intercom.Company{{CompanyID: "5"}}}})
+	users, err := ic.Companies.ListUsersByCompanyID(ctx, "5", intercom.PageParams{})
+	if err != nil || len(users.Users) != 1 || users.Users[0].UserID != "27" {
+		t.Errorf("Company Users were %+v, error %v", users.Users, err)
+	}
+}
+
+func TestTagging(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	server.AddUser(intercom.User{UserID: "27"})
+
+	tag, err := ic.Tags.Tag(ctx, &intercom.TaggingList{Name: "VIP", Users: []intercom.Tagging{{UserID: "27"}}})
+	if err != nil || tag.Name != "VIP" {
+		t.Errorf("Tag was %+v, error %v", tag, err)
+	}
+	users, _ := ic.Users.ListByTag(ctx, tag.ID, intercom.PageParams{})
+	if len(users.Users) != 1 {
+		t.Errorf("Tagged Users were %+v", users.Users)
+	}
+}
+
+func TestConversationReply(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A"})
+	assignee := server.AddAdmin(intercom.Admin{Name: "Admin B"})
+	convo := server.AddConversation(intercom.Conversation{Open: true})
+
+	ic.Conversations.Reply(ctx, convo.ID, &admin, intercom.CONVERSATION_COMMENT, "Hello")
+	ic.Conversations.Assign(ctx, convo.ID, &admin, &assignee)
+	closed, err := ic.Conversations.Close(ctx, convo.ID, &admin)
+	if err != nil || closed.Open || closed.Assignee.ID != assignee.ID {
+		t.Errorf("Conversation was %+v, error %v", closed, err)
+	}
+	if parts := closed.ConversationParts.Parts; len(parts) != 3 || parts[0].Body != "Hello" {
+		t.Errorf("Conversation parts were %+v", parts)
+	}
+	list, _ := ic.Conversations.ListByAdmin(ctx, &assignee, intercom.SHOW_CLOSED, intercom.PageParams{})
+	if len(list.Conversations) != 1 {
+		t.Errorf("Admin Conversations were %+v", list.Conversations)
+	}
+}
+
+func TestConversationSnoozeAndPriority(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A"})
+	convo := server.AddConversation(intercom.Conversation{Open: true, State: "open"})
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	snoozed, err := ic.Conversations.Snooze(ctx, convo.ID, &admin, until)
+	if err != nil || snoozed.State != "snoozed" || snoozed.SnoozedUntil != until.Unix() {
+		t.Errorf("Conversation was %+v, error %v", snoozed, err)
+	}
+	priority, _ := ic.Conversations.MarkPriority(ctx, convo.ID, &admin, true)
+	unsnoozed, _ := ic.Conversations.Unsnooze(ctx, convo.ID, &admin)
+	if priority.Priority != "priority" || unsnoozed.State != "open" || unsnoozed.SnoozedUntil != 0 {
+		t.Errorf("Conversation was %+v, then %+v", priority, unsnoozed)
+	}
+	if parts := unsnoozed.ConversationParts.Parts; len(parts) != 3 || parts[0].PartType != "snoozed" {
+		t.Errorf("Conversation parts were %+v", parts)
+	}
+	if _, err := ic.Conversations.RunAssignmentRules(ctx, convo.ID); err != nil {
+		t.Errorf("Error running assignment rules: %v", err)
+	}
+}
+
+func TestDataAttributeValidation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ic.Option(intercom.ValidateCustomAttributes(true))
+	ctx := context.Background()
+	server.AddDataAttribute(intercom.DataAttribute{Name: "seats", Model: "contact", DataType: "integer"})
+
+	if _, err := ic.Users.Save(ctx, &intercom.User{UserID: "27", CustomAttributes: map[string]interface{}{"plan": "pro"}}); err == nil {
+		t.Errorf("Expected an error saving an unknown custom attribute")
+	}
+	plan, err := ic.DataAttributes.Create(ctx, &intercom.DataAttribute{Name: "plan", Model: "contact", DataType: "string", Options: []string{"free", "pro"}})
+	if err != nil || plan.ID == "" || plan.FullName != "custom_attributes.plan" {
+		t.Fatalf("DataAttribute was %+v, error %v", plan, err)
+	}
+	if _, err := ic.Users.Save(ctx, &intercom.User{UserID: "27", CustomAttributes: map[string]interface{}{"plan": "pro", "seats": 5}}); err != nil {
+		t.Errorf("Error saving valid custom attributes: %v", err)
+	}
+	plan.Archived = true
+	if _, err := ic.DataAttributes.Update(ctx, &plan); err != nil {
+		t.Errorf("Error archiving DataAttribute: %v", err)
+	}
+	if list, _ := ic.DataAttributes.List(ctx, intercom.MODEL_CONTACT, false); len(list.DataAttributes) != 1 {
+		t.Errorf("DataAttributes were %+v, expected only seats", list.DataAttributes)
+	}
+	if _, err := ic.Users.Save(ctx, &intercom.User{UserID: "27", CustomAttributes: map[string]interface{}{"plan": "free"}}); err == nil {
+		t.Errorf("Expected an error saving an archived custom attribute")
+	}
+}
+
+func TestAdmins(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A", Email: "admin_a@example.io"})
+
+	me, err := ic.Admins.Me(ctx)
+	if err != nil || me.Admin.ID != admin.ID || me.App.IDCode == "" {
+		t.Errorf("Me was %+v, error %v", me, err)
+	}
+	away, err := ic.Admins.SetAway(ctx, admin.ID.String(), true, true)
+	if err != nil || !away.AwayModeEnabled || !away.AwayModeReassign {
+		t.Errorf("Admin was %+v, error %v", away, err)
+	}
+	found, err := ic.Admins.Find(ctx, admin.ID.String())
+	if err != nil || found.Email != admin.Email || !found.AwayModeEnabled {
+		t.Errorf("Admin was %+v, error %v", found, err)
+	}
+	if _, err := ic.Admins.Find(ctx, "404"); err == nil {
+		t.Errorf("Expected an error finding a missing Admin")
+	}
+}
+
+func TestTeamAssignment(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A"})
+	team := server.AddTeam(intercom.Team{Name: "Support", AdminIDs: []json.Number{admin.ID}})
+	convo := server.AddConversation(intercom.Conversation{Open: true})
+
+	teams, err := ic.Teams.List(ctx)
+	if err != nil || len(teams.Teams) != 1 || !teams.Teams[0].HasAdmin(&admin) {
+		t.Errorf("Teams were %+v, error %v", teams, err)
+	}
+	if found, err := ic.Teams.Find(ctx, team.ID.String()); err != nil || found.Name != "Support" {
+		t.Errorf("Team was %+v, error %v", found, err)
+	}
+	assigned, err := ic.Conversations.AssignToTeam(ctx, convo.ID, &admin, &team, &admin)
+	if err != nil || assigned.TeamAssigneeID != team.ID.String() || assigned.Assignee.ID != admin.ID {
+		t.Errorf("Conversation was %+v, error %v", assigned, err)
+	}
+	unassigned, _ := ic.Conversations.Unassign(ctx, convo.ID, &admin)
+	if !unassigned.Assignee.IsNobodyAdmin() || unassigned.TeamAssigneeID != team.ID.String() {
+		t.Errorf("Conversation was assigned to %s and team %s", unassigned.Assignee, unassigned.TeamAssigneeID)
+	}
+}
+
+func TestCreateAndRedactConversation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	user := server.AddUser(intercom.User{UserID: "27"})
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A"})
+
+	convo, err := ic.Conversations.Create(ctx, &intercom.User{UserID: "27"}, "My card is 4242 4242 4242 4242")
+	if err != nil || convo.User.ID != user.ID || convo.ConversationMessage.Body == "" || len(server.Conversations()) != 1 {
+		t.Fatalf("Conversation was %+v, error %v", convo, err)
+	}
+	convo, _ = ic.Conversations.Reply(ctx, convo.ID, &user, intercom.CONVERSATION_COMMENT, "And the CVC is 123")
+	convo, _ = ic.Conversations.Reply(ctx, convo.ID, &admin, intercom.CONVERSATION_COMMENT, "Thanks")
+
+	redacted, err := ic.Conversations.Redact(ctx, intercom.RedactPart(convo.ID, convo.ConversationParts.Parts[0].ID))
+	if parts := redacted.ConversationParts.Parts; err != nil || !parts[0].Redacted || parts[0].Body != "" || parts[1].Redacted {
+		t.Errorf("Conversation parts were %+v, error %v", parts, err)
+	}
+	redacted, err = ic.Conversations.Redact(ctx, intercom.RedactSource(convo.ID, convo.ConversationMessage.ID))
+	if err != nil || redacted.ConversationMessage.Body != "" {
+		t.Errorf("Conversation source was %+v, error %v", redacted.ConversationMessage, err)
+	}
+	if _, err := ic.Conversations.Redact(ctx, intercom.RedactPart(convo.ID, "missing")); err == nil {
+		t.Errorf("Redacted a missing part")
+	}
+}
+
+func TestConversationSearch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	for i := int64(1); i <= 5; i++ {
+		server.AddConversation(intercom.Conversation{Open: i%2 == 1, CreatedAt: i * 100})
+	}
+
+	search := intercom.NewSearch(intercom.And(
+		intercom.Field("open").Eq(true),
+		intercom.Field("created_at").GreaterThan(100),
+	)).PerPage(1)
+	list, err := ic.Conversations.Search(ctx, search)
+	if err != nil || list.TotalCount != 2 || len(list.Conversations) != 1 || list.Conversations[0].CreatedAt != 300 {
+		t.Errorf("Search was %+v, error %v", list, err)
+	}
+	created := []int64{}
+	it := ic.Conversations.IterateSearch(ctx, search)
+	for it.Next() {
+		created = append(created, it.Value().CreatedAt)
+	}
+	if it.Err() != nil || fmt.Sprint(created) != "[300 500]" {
+		t.Errorf("Iterated %v, error %v", created, it.Err())
+	}
+}
+
+func TestContactSearch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	server.AddContact(intercom.Contact{Email: "alice@example.io", CustomAttributes: map[string]interface{}{"plan": "pro"}})
+	server.AddContact(intercom.Contact{Email: "bob@example.io", CustomAttributes: map[string]interface{}{"plan": "free"}})
+	server.AddContact(intercom.Contact{Email: "carol@example.org", CustomAttributes: map[string]interface{}{"plan": "pro"}})
+	server.AddUser(intercom.User{UserID: "4", Email: "dave@example.io", CustomAttributes: map[string]interface{}{"plan": "pro"}})
+
+	search := intercom.NewSearch(intercom.Field("custom_attributes.plan").Eq("pro").And(intercom.Field("email").EndsWith(".io")))
+	list, err := ic.Contacts.Search(context.Background(), search)
+	if err != nil || list.TotalCount != 2 || list.Contacts[0].Email != "dave@example.io" || list.Contacts[0].UserID != "4" || list.Contacts[1].Email != "alice@example.io" {
+		t.Errorf("Search was %+v, error %v", list, err)
+	}
+	leads, err := ic.Contacts.Search(context.Background(), intercom.NewSearch(intercom.Field("role").Eq("lead")))
+	if err != nil || leads.TotalCount != 3 {
+		t.Errorf("Search for leads was %+v, error %v", leads, err)
+	}
+}
+
+func TestCounts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Admin A"})
+	server.AddTag(intercom.Tag{Name: "VIP"})
+	server.AddCompany(intercom.Company{CompanyID: "6", Name: "Acme"})
+	server.AddUser(intercom.User{UserID: "1", Tags: &intercom.TagList{Tags: []intercom.Tag{{Name: "VIP"}}},
+		Companies: &intercom.CompanyList{Companies: []intercom.Company{{Name: "Acme"}}}})
+	server.AddUser(intercom.User{UserID: "2"})
+	server.AddConversation(intercom.Conversation{Open: true, Assignee: admin})
+	server.AddConversation(intercom.Conversation{})
+
+	app, err := ic.Counts.App(ctx)
+	if err != nil || app.User != 2 || app.Tag != 1 {
+		t.Errorf("App count was %+v, error %v", app, err)
+	}
+	tags, err := ic.Counts.UsersByTag(ctx)
+	if err != nil || fmt.Sprint(tags) != "[{VIP 1 }]" {
+		t.Errorf("Tag counts were %v, error %v", tags, err)
+	}
+	companies, err := ic.Counts.UsersByCompany(ctx)
+	if err != nil || fmt.Sprint(companies) != "[{Acme 1 6}]" {
+		t.Errorf("Company counts were %v, error %v", companies, err)
+	}
+	convos, _ := ic.Counts.Conversations(ctx)
+	if convos != (intercom.ConversationCount{Open: 1, Closed: 1, Assigned: 1, Unassigned: 1}) {
+		t.Errorf("Conversation count was %+v", convos)
+	}
+	admins, _ := ic.Counts.ConversationsByAdmin(ctx)
+	if len(admins) != 1 || admins[0].ID != admin.ID || admins[0].Open != 1 {
+		t.Errorf("Admin conversation counts were %+v", admins)
+	}
+}
+
+func TestBulkJob(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ctx := context.Background()
+
+	job, err := ic.Jobs.NewUserJob(ctx, intercom.NewUserJobItem(&intercom.User{UserID: "27"}, intercom.JOB_POST))
+	if err != nil || job.State != "completed" {
+		t.Errorf("Job was %+v, error %v", job, err)
+	}
+	if _, err := ic.Jobs.AppendEvents(ctx, job.ID, intercom.NewEventJobItem(&intercom.Event{UserID: "27", EventName: "signed-up"})); err != nil {
+		t.Errorf("Error appending to Job: %v", err)
+	}
+	if len(server.Users()) != 1 || len(server.Events()) != 1 {
+		t.Errorf("Job created %d Users and %d Events", len(server.Users()), len(server.Events()))
+	}
+	if found, _ := ic.Jobs.Find(ctx, job.ID); found.ID != job.ID {
+		t.Errorf("Found Job %+v", found)
+	}
+}
+
+func TestFail(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ic.Option(intercom.SetRetryPolicy(interfaces.RetryPolicy{MaxAttempts: 1}))
+	ctx := context.Background()
+
+	server.Fail("GET", "/admins", 1, 401, "unauthorized")
+	_, err := ic.Admins.List(ctx)
+	herr, ok := err.(interfaces.HTTPError)
+	if !errors.Is(err, intercom.ErrUnauthorized) || !ok || herr.Code != "unauthorized" {
+		t.Errorf("Error was %v, expected unauthorized", err)
+	}
+	if _, err := ic.Admins.List(ctx); err != nil {
+		t.Errorf("Error was %v after failure was used up", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ic := server.Client()
+	ic.Option(intercom.SetRetryPolicy(interfaces.RetryPolicy{MaxAttempts: 1}))
+	ctx := context.Background()
+
+	server.SetRateLimit(2, time.Minute)
+	ic.Admins.List(ctx)
+	if remaining := ic.RateLimit().Remaining; remaining != 1 {
+		t.Errorf("Remaining was %d, expected 1", remaining)
+	}
+	ic.Admins.List(ctx)
+	if _, err := ic.Admins.List(ctx); !errors.Is(err, intercom.ErrRateLimited) {
+		t.Errorf("Error was %v, expected %v", err, intercom.ErrRateLimited)
+	}
+	if n := len(server.Requests()); n != 3 {
+		t.Errorf("Server received %d requests, expected 3", n)
+	}
+}
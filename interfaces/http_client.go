@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -19,13 +20,34 @@ type HTTPClient interface {
 	Delete(context.Context, string, interface{}) ([]byte, error)
 }
 
+// Putter is implemented by HTTPClients that can make PUT requests, which are needed by
+// some API version 2.0 endpoints, such as updating unified Contacts. IntercomHTTPClient implements it.
+type Putter interface {
+	Put(context.Context, string, interface{}) ([]byte, error)
+}
+
+// Put makes a PUT request with httpClient, or returns an error if it does not implement Putter.
+func Put(ctx context.Context, httpClient HTTPClient, url string, body interface{}) ([]byte, error) {
+	putter, ok := httpClient.(Putter)
+	if !ok {
+		return nil, fmt.Errorf("[intercom] cannot PUT %s, %T does not implement interfaces.Putter", url, httpClient)
+	}
+	return putter.Put(ctx, url, body)
+}
+
 type IntercomHTTPClient struct {
 	*http.Client
 	BaseURI       *string
 	AppID         string
 	APIKey        string
 	ClientVersion *string
-	Debug         *bool
+	// Deprecated: Debug prints requests and responses in full to stdout, use Logger instead.
+	Debug       *bool
+	RetryPolicy *RetryPolicy
+	RateLimiter *RateLimiter
+	TokenSource *TokenSource
+	Version     *string
+	Logger      *RequestLogger
 }
 
 func NewIntercomHTTPClient(appID, apiKey string, baseURI, clientVersion *string, debug *bool) IntercomHTTPClient {
@@ -36,11 +58,18 @@ func (c IntercomHTTPClient) UserAgentHeader() string {
 	return fmt.Sprintf("intercom-go/%s", *c.ClientVersion)
 }
 
+// APIVersion is the Intercom-Version sent with each request, or empty to use the workspace default.
+func (c IntercomHTTPClient) APIVersion() string {
+	if c.Version == nil {
+		return ""
+	}
+	return *c.Version
+}
+
 func (c IntercomHTTPClient) Get(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
 	// Setup request
 	req, _ := http.NewRequest("GET", *c.BaseURI+url, nil)
 	req = req.WithContext(ctx)
-	req.SetBasicAuth(c.AppID, c.APIKey)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", c.UserAgentHeader())
 	addQueryParams(req, queryParams)
@@ -48,22 +77,7 @@ func (c IntercomHTTPClient) Get(ctx context.Context, url string, queryParams int
 		fmt.Printf("%s %s\n", req.Method, req.URL)
 	}
 
-	// Do request
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	data, err := c.readAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode >= 400 {
-		return nil, c.parseResponseError(data, resp.StatusCode)
-	}
-	return data, err
+	return c.do(req, url)
 }
 
 func addQueryParams(req *http.Request, params interface{}) {
@@ -75,6 +89,10 @@ func (c IntercomHTTPClient) Patch(ctx context.Context, url string, body interfac
 	return c.postOrPatch(ctx, "PATCH", url, body)
 }
 
+func (c IntercomHTTPClient) Put(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return c.postOrPatch(ctx, "PUT", url, body)
+}
+
 func (c IntercomHTTPClient) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
 	return c.postOrPatch(ctx, "POST", url, body)
 }
@@ -92,7 +110,6 @@ func (c IntercomHTTPClient) postOrPatch(ctx context.Context, method, url string,
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(c.AppID, c.APIKey)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("User-Agent", c.UserAgentHeader())
@@ -100,29 +117,13 @@ func (c IntercomHTTPClient) postOrPatch(ctx context.Context, method, url string,
 		fmt.Printf("%s %s %s\n", req.Method, req.URL, buffer)
 	}
 
-	// Do request
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	data, err := c.readAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode >= 400 {
-		return nil, c.parseResponseError(data, resp.StatusCode)
-	}
-	return data, err
+	return c.do(req, url)
 }
 
 func (c IntercomHTTPClient) Delete(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
 	// Setup request
 	req, _ := http.NewRequest("DELETE", *c.BaseURI+url, nil)
 	req = req.WithContext(ctx)
-	req.SetBasicAuth(c.AppID, c.APIKey)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", c.UserAgentHeader())
 	addQueryParams(req, queryParams)
@@ -130,22 +131,93 @@ func (c IntercomHTTPClient) Delete(ctx context.Context, url string, queryParams
 		fmt.Printf("%s %s\n", req.Method, req.URL)
 	}
 
+	return c.do(req, url)
+}
+
+// do sends the request, retrying according to the RetryPolicy.
+func (c IntercomHTTPClient) do(req *http.Request, url string) ([]byte, error) {
+	policy := RetryPolicy{}
+	if c.RetryPolicy != nil {
+		policy = *c.RetryPolicy
+	}
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		data, statusCode, resp, err := c.attempt(req, url)
+		retrying := policy.shouldRetry(req, url, attempt, statusCode, err)
+		c.Logger.log(req, url, attempt-1, time.Since(start), resp, data, err, retrying)
+		recordResponseInfo(req.Context(), attempt-1, resp, err)
+		if !retrying {
+			return data, err
+		}
+		backoff := policy.backoff(attempt)
+		if *c.Debug {
+			fmt.Printf("Retrying %s %s in %s (attempt %d failed: %v)\n", req.Method, req.URL, backoff, attempt, err)
+		}
+		if err := sleepContext(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt makes a single request, returning the status code and response if one was received.
+func (c IntercomHTTPClient) attempt(req *http.Request, url string) ([]byte, int, *http.Response, error) {
+	// Rewind the body for retries
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		req.Body = body
+	}
+
+	if err := c.authorize(req); err != nil {
+		return nil, 0, nil, err
+	}
+	if version := c.APIVersion(); version != "" {
+		req.Header.Set("Intercom-Version", version)
+	}
+
+	// Wait for the rate limit window to reset if exhausted
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.waitForReset(req.Context()); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
 	// Do request
 	resp, err := c.Client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, nil, err
 	}
 	defer resp.Body.Close()
+	if c.RateLimiter != nil {
+		c.RateLimiter.update(resp.Header)
+	}
 
 	// Read response
 	data, err := c.readAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, 0, resp, err
 	}
 	if resp.StatusCode >= 400 {
-		return nil, c.parseResponseError(data, resp.StatusCode)
+		return nil, resp.StatusCode, resp, ParseResponseError(data, resp, url)
 	}
-	return data, err
+	return data, resp.StatusCode, resp, err
+}
+
+// authorize uses a Bearer token from the TokenSource if there is one,
+// or otherwise Basic auth with the AppID and APIKey.
+func (c IntercomHTTPClient) authorize(req *http.Request) error {
+	if c.TokenSource == nil || *c.TokenSource == nil {
+		req.SetBasicAuth(c.AppID, c.APIKey)
+		return nil
+	}
+	token, err := (*c.TokenSource).Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
 }
 
 type IntercomError interface {
@@ -155,20 +227,6 @@ type IntercomError interface {
 	GetMessage() string
 }
 
-func (c IntercomHTTPClient) parseResponseError(data []byte, statusCode int) IntercomError {
-	errorList := HTTPErrorList{}
-	err := json.Unmarshal(data, &errorList)
-	if err != nil {
-		return NewUnknownHTTPError(statusCode)
-	}
-	if len(errorList.Errors) == 0 {
-		return NewUnknownHTTPError(statusCode)
-	}
-	httpError := errorList.Errors[0]
-	httpError.StatusCode = statusCode
-	return httpError // only care about the first
-}
-
 func (c IntercomHTTPClient) readAll(body io.Reader) ([]byte, error) {
 	b, err := ioutil.ReadAll(body)
 	if *c.Debug {
@@ -1,19 +1,45 @@
 package interfaces
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// Sentinel errors that an HTTPError matches using errors.Is, based on its status code.
+var (
+	ErrUnauthorized = errors.New("intercom: unauthorized")
+	ErrNotFound     = errors.New("intercom: not found")
+	ErrConflict     = errors.New("intercom: conflict")
+	ErrRateLimited  = errors.New("intercom: rate limited")
+)
+
 type HTTPErrorList struct {
-	Type   string      `json:"type"`
-	Errors []HTTPError `json:"errors"`
+	Type      string      `json:"type"`
+	RequestID string      `json:"request_id"`
+	Errors    []HTTPError `json:"errors"`
 }
 
+// HTTPError is an error returned from the API.
+// Code and Message are taken from the first error returned, with all of them available from GetErrors.
+// HTTPError remains comparable with ==, so its slice and map fields are held in Details.
 type HTTPError struct {
 	StatusCode int
 	Code       string `json:"code"`
 	Message    string `json:"message"`
+	Field      string `json:"field,omitempty"`
+
+	RequestID string            `json:"-"`
+	Method    string            `json:"-"`
+	Path      string            `json:"-"`
+	Details   *HTTPErrorDetails `json:"-"`
+}
+
+// HTTPErrorDetails holds every error returned from the API, and the response headers.
+type HTTPErrorDetails struct {
+	Errors []HTTPError
+	Header http.Header
 }
 
 func NewUnknownHTTPError(statusCode int) HTTPError {
@@ -24,10 +50,52 @@ func NewUnknownHTTPError(statusCode int) HTTPError {
 	return HTTPError{Code: "Unknown", Message: message, StatusCode: statusCode}
 }
 
+// ParseResponseError builds an HTTPError from a failed response and its body, an Intercom error list,
+// for a request to path. It is used by IntercomHTTPClient, and by other clients of Intercom's APIs,
+// such as the oauth package, so that their errors match in the same way.
+func ParseResponseError(data []byte, resp *http.Response, path string) HTTPError {
+	errorList := HTTPErrorList{}
+	httpError := NewUnknownHTTPError(resp.StatusCode)
+	details := &HTTPErrorDetails{Header: resp.Header}
+	if err := json.Unmarshal(data, &errorList); err == nil && len(errorList.Errors) > 0 {
+		for i := range errorList.Errors {
+			errorList.Errors[i].StatusCode = resp.StatusCode
+		}
+		httpError = errorList.Errors[0]
+		details.Errors = errorList.Errors
+	}
+	httpError.RequestID = errorList.RequestID
+	if httpError.RequestID == "" {
+		httpError.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	if resp.Request != nil {
+		httpError.Method = resp.Request.Method
+	}
+	httpError.Path = path
+	httpError.Details = details
+	return httpError
+}
+
 func (e HTTPError) Error() string {
 	return fmt.Sprintf("%d: %s, %s", e.StatusCode, e.Code, e.Message)
 }
 
+// Is allows an HTTPError to be compared to ErrUnauthorized, ErrNotFound,
+// ErrConflict and ErrRateLimited with errors.Is.
+func (e HTTPError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
 func (e HTTPError) GetStatusCode() int {
 	return e.StatusCode
 }
@@ -39,3 +107,24 @@ func (e HTTPError) GetCode() string {
 func (e HTTPError) GetMessage() string {
 	return e.Message
 }
+
+// GetErrors returns every error returned from the API, or nil if none were decoded.
+func (e HTTPError) GetErrors() []HTTPError {
+	if e.Details == nil {
+		return nil
+	}
+	return e.Details.Errors
+}
+
+// GetHeader returns the headers of the failed response, or nil if they are unknown.
+func (e HTTPError) GetHeader() http.Header {
+	if e.Details == nil {
+		return nil
+	}
+	return e.Details.Header
+}
+
+// GetRequestID returns the ID Intercom assigned the failed request, useful when contacting support.
+func (e HTTPError) GetRequestID() string {
+	return e.RequestID
+}
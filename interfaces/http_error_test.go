@@ -0,0 +1,109 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPErrorIs(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		target     error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+	for _, c := range cases {
+		var err error = HTTPError{StatusCode: c.statusCode}
+		if !errors.Is(err, c.target) {
+			t.Errorf("%d was not %v", c.statusCode, c.target)
+		}
+		if errors.Is(err, errors.New(c.target.Error())) {
+			t.Errorf("%d matched an unrelated error", c.statusCode)
+		}
+	}
+	if errors.Is(HTTPError{StatusCode: http.StatusNotFound}, ErrConflict) {
+		t.Errorf("404 was %v", ErrConflict)
+	}
+}
+
+func TestParseResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "header-request-id")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type":"error.list","request_id":"b2h3kaer1f7e4bngjqkg","errors":[{"code":"not_found","message":"User Not Found"},{"code":"parameter_invalid","message":"Email invalid","field":"email"}]}`))
+	}))
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	_, err := client.Get(context.Background(), "/users", nil)
+
+	var httpError HTTPError
+	if !errors.As(err, &httpError) {
+		t.Fatalf("Error was %v, expected HTTPError", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Error was not ErrNotFound")
+	}
+	if httpError.Code != "not_found" || httpError.Message != "User Not Found" {
+		t.Errorf("Code was %s and Message %s, expected the first error", httpError.Code, httpError.Message)
+	}
+	if errs := httpError.GetErrors(); len(errs) != 2 || errs[1].Field != "email" || errs[1].StatusCode != http.StatusNotFound {
+		t.Errorf("Errors was %v, expected both errors", errs)
+	}
+	if httpError.GetHeader().Get("X-Request-Id") != "header-request-id" {
+		t.Errorf("Header was %v", httpError.GetHeader())
+	}
+	if httpError.GetRequestID() != "b2h3kaer1f7e4bngjqkg" {
+		t.Errorf("RequestID was %s, expected b2h3kaer1f7e4bngjqkg", httpError.RequestID)
+	}
+	if httpError.Method != "GET" || httpError.Path != "/users" {
+		t.Errorf("Request was %s %s, expected GET /users", httpError.Method, httpError.Path)
+	}
+}
+
+func TestParseResponseErrorWithoutBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "header-request-id")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	_, err := client.Delete(context.Background(), "/users/1", nil)
+
+	httpError, ok := err.(HTTPError)
+	if !ok {
+		t.Fatalf("Error was %v, expected HTTPError", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Error was not ErrRateLimited")
+	}
+	if httpError.Code != "Unknown" {
+		t.Errorf("Code was %s, expected Unknown", httpError.Code)
+	}
+	if httpError.RequestID != "header-request-id" {
+		t.Errorf("RequestID was %s, expected header-request-id", httpError.RequestID)
+	}
+	if httpError.Method != "DELETE" || httpError.Path != "/users/1" {
+		t.Errorf("Request was %s %s, expected DELETE /users/1", httpError.Method, httpError.Path)
+	}
+}
+
+func TestHTTPErrorComparable(t *testing.T) {
+	notFound := HTTPError{StatusCode: http.StatusNotFound, Code: "not_found", Details: &HTTPErrorDetails{Header: http.Header{}}}
+	var err error = notFound
+	if err != notFound {
+		t.Errorf("HTTPError was not equal to itself")
+	}
+	seen := map[HTTPError]bool{notFound: true}
+	if !seen[notFound] {
+		t.Errorf("HTTPError was not usable as a map key")
+	}
+	if (HTTPError{}).GetErrors() != nil || (HTTPError{}).GetHeader() != nil {
+		t.Errorf("HTTPError without Details had errors or headers")
+	}
+}
@@ -0,0 +1,162 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// DefaultRedactedFields are the JSON fields redacted from logged bodies by default,
+// as they hold personal data or credentials.
+var DefaultRedactedFields = []string{
+	"email", "phone", "name", "pseudonym", "avatar", "location_data", "last_seen_ip",
+	"user_agent_data", "last_seen_user_agent", "social_profiles", "custom_attributes",
+	"metadata", "body", "subject", "access_token", "client_secret",
+}
+
+// redactedHeaders are never logged.
+var redactedHeaders = []string{"Authorization", "Cookie"}
+
+// RequestLogger logs each request made by the IntercomHTTPClient to a slog.Logger,
+// with its method, path, status, latency, request ID and retry count.
+type RequestLogger struct {
+	Logger *slog.Logger
+	// LogBodies adds the query, request headers and request and response bodies to each log,
+	// with RedactedFields and Authorization headers redacted.
+	LogBodies bool
+	// RedactedFields are the JSON fields and query parameters whose values are redacted,
+	// defaulting to DefaultRedactedFields.
+	RedactedFields []string
+}
+
+// log logs a single attempt at a request. Failed attempts that will be retried are logged as warnings.
+func (l *RequestLogger) log(req *http.Request, path string, retry int, latency time.Duration, resp *http.Response, data []byte, err error, retrying bool) {
+	if l == nil || l.Logger == nil {
+		return
+	}
+	ctx := req.Context()
+	level := slog.LevelInfo
+	switch {
+	case err != nil && retrying:
+		level = slog.LevelWarn
+	case err != nil:
+		level = slog.LevelError
+	}
+	if !l.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Int("status", 0),
+		slog.Duration("latency", latency),
+		slog.Int("retry", retry),
+	}
+	var herr HTTPError
+	switch {
+	case errors.As(err, &herr):
+		attrs[2] = slog.Int("status", herr.StatusCode)
+		attrs = append(attrs, slog.String("request_id", herr.RequestID))
+	case resp != nil:
+		attrs[2] = slog.Int("status", resp.StatusCode)
+		attrs = append(attrs, slog.String("request_id", resp.Header.Get("X-Request-Id")))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if l.LogBodies {
+		attrs = append(attrs,
+			slog.String("query", l.redactQuery(req)),
+			slog.Any("request_headers", redactHeaders(req.Header)),
+			slog.String("request_body", l.redactBody(requestBody(req))),
+			slog.String("response_body", l.redactBody(data)),
+		)
+	}
+	l.Logger.LogAttrs(ctx, level, "intercom request", attrs...)
+}
+
+func (l *RequestLogger) redactedFields() map[string]bool {
+	fields := l.RedactedFields
+	if fields == nil {
+		fields = DefaultRedactedFields
+	}
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[strings.ToLower(field)] = true
+	}
+	return set
+}
+
+func (l *RequestLogger) redactQuery(req *http.Request) string {
+	values := req.URL.Query()
+	fields := l.redactedFields()
+	for k := range values {
+		if fields[strings.ToLower(k)] {
+			values.Set(k, redacted)
+		}
+	}
+	return values.Encode()
+}
+
+// redactBody redacts fields from a JSON body. Bodies that are not JSON are redacted entirely.
+func (l *RequestLogger) redactBody(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+	b, _ := json.Marshal(redactValue(v, l.redactedFields()))
+	return string(b)
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if fields[strings.ToLower(k)] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(value, fields)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i], fields)
+		}
+	}
+	return v
+}
+
+func redactHeaders(header http.Header) http.Header {
+	h := header.Clone()
+	for _, k := range redactedHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, redacted)
+		}
+	}
+	return h
+}
+
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	return data
+}
@@ -0,0 +1,117 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	lines := []map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("Log line was not JSON: %s", line)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func testLogger(buf *bytes.Buffer, logBodies bool) *RequestLogger {
+	return &RequestLogger{Logger: slog.New(slog.NewJSONHandler(buf, nil)), LogBodies: logBodies}
+}
+
+func TestLogRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1")
+		w.Write([]byte(`{"type":"user","email":"jamie@example.io"}`))
+	}))
+	defer server.Close()
+	buf := &bytes.Buffer{}
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Logger = testLogger(buf, false)
+	client.Post(context.Background(), "/users", map[string]string{"email": "jamie@example.io"})
+
+	lines := logLines(t, buf)
+	if len(lines) != 1 {
+		t.Fatalf("Logged %d lines, expected 1", len(lines))
+	}
+	line := lines[0]
+	if line["level"] != "INFO" || line["method"] != "POST" || line["path"] != "/users" || line["status"] != float64(200) ||
+		line["request_id"] != "req_1" || line["retry"] != float64(0) || line["latency"] == nil {
+		t.Errorf("Log was %v", line)
+	}
+	if strings.Contains(buf.String(), "jamie@example.io") {
+		t.Errorf("Log contained a body without LogBodies: %s", buf)
+	}
+}
+
+func TestLogBodiesRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type":"user","user_id":"27","email":"jamie@example.io","custom_attributes":{"plan":"pro"}}`))
+	}))
+	defer server.Close()
+	buf := &bytes.Buffer{}
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Logger = testLogger(buf, true)
+	client.Get(context.Background(), "/users", struct {
+		Email string `url:"email"`
+	}{"jamie@example.io"})
+
+	for _, secret := range []string{"jamie@example.io", "pro", "YXBwSUQ6YXBpS2V5"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("Log contained %s: %s", secret, buf)
+		}
+	}
+	line := logLines(t, buf)[0]
+	if body, _ := line["response_body"].(string); !strings.Contains(body, `"user_id":"27"`) {
+		t.Errorf("Response body was %v", line["response_body"])
+	}
+	if line["query"] != "email=REDACTED" {
+		t.Errorf("Query was %v", line["query"])
+	}
+}
+
+func TestLogRetries(t *testing.T) {
+	calls := 0
+	server := failingServer(1, 503, &calls)
+	defer server.Close()
+	buf := &bytes.Buffer{}
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	client.Logger = testLogger(buf, false)
+	client.Get(context.Background(), "/users", nil)
+
+	lines := logLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("Logged %d lines, expected 2", len(lines))
+	}
+	if lines[0]["level"] != "WARN" || lines[0]["status"] != float64(503) || lines[0]["retry"] != float64(0) {
+		t.Errorf("First log was %v", lines[0])
+	}
+	if lines[1]["level"] != "INFO" || lines[1]["retry"] != float64(1) {
+		t.Errorf("Second log was %v", lines[1])
+	}
+}
+
+func TestLogError(t *testing.T) {
+	calls := 0
+	server := failingServer(1, 404, &calls)
+	defer server.Close()
+	buf := &bytes.Buffer{}
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Logger = testLogger(buf, false)
+	client.Get(context.Background(), "/users/1", nil)
+
+	if line := logLines(t, buf)[0]; line["level"] != "ERROR" || line["status"] != float64(404) || line["error"] == nil {
+		t.Errorf("Log was %v", line)
+	}
+}
@@ -0,0 +1,143 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// A Call is a single request made through an HTTPClient.
+type Call struct {
+	Method string
+	Path   string
+	// Params are the query parameters for GET and DELETE, and the body for POST, PATCH and PUT.
+	Params interface{}
+	// Operation is the repository operation the Call is made for, if set with WithOperation.
+	Operation Operation
+}
+
+// An Operation names a repository operation, such as users.find or conversations.reply.
+type Operation struct {
+	Resource string
+	Action   string
+}
+
+func (o Operation) String() string {
+	return o.Resource + "." + o.Action
+}
+
+type operationKey struct{}
+
+// WithOperation returns a context in which Calls are made for the named operation.
+// Each repository sets the operation before making its Calls.
+func WithOperation(ctx context.Context, resource, action string) context.Context {
+	return context.WithValue(ctx, operationKey{}, Operation{Resource: resource, Action: action})
+}
+
+// OperationFromContext returns the operation set with WithOperation, or a zero Operation.
+func OperationFromContext(ctx context.Context) Operation {
+	operation, _ := ctx.Value(operationKey{}).(Operation)
+	return operation
+}
+
+// RoundTrip makes a Call, returning the response body.
+type RoundTrip func(ctx context.Context, call Call) ([]byte, error)
+
+// Middleware wraps a RoundTrip, and may inspect or change the Call and its result,
+// or answer the Call without calling next.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Chain returns an HTTPClient passing every Call through the middleware before
+// making it with httpClient. The first middleware is the outermost.
+func Chain(httpClient HTTPClient, middleware ...Middleware) HTTPClient {
+	if len(middleware) == 0 {
+		return httpClient
+	}
+	roundTrip := func(ctx context.Context, call Call) ([]byte, error) {
+		switch call.Method {
+		case "GET":
+			return httpClient.Get(ctx, call.Path, call.Params)
+		case "POST":
+			return httpClient.Post(ctx, call.Path, call.Params)
+		case "PATCH":
+			return httpClient.Patch(ctx, call.Path, call.Params)
+		case "PUT":
+			return Put(ctx, httpClient, call.Path, call.Params)
+		case "DELETE":
+			return httpClient.Delete(ctx, call.Path, call.Params)
+		}
+		return nil, fmt.Errorf("unsupported method %s", call.Method)
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		roundTrip = middleware[i](roundTrip)
+	}
+	return chainHTTPClient{httpClient: httpClient, roundTrip: roundTrip}
+}
+
+type chainHTTPClient struct {
+	httpClient HTTPClient
+	roundTrip  RoundTrip
+}
+
+func (c chainHTTPClient) Get(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, Call{Method: "GET", Path: url, Params: queryParams, Operation: OperationFromContext(ctx)})
+}
+
+func (c chainHTTPClient) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, Call{Method: "POST", Path: url, Params: body, Operation: OperationFromContext(ctx)})
+}
+
+func (c chainHTTPClient) Patch(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, Call{Method: "PATCH", Path: url, Params: body, Operation: OperationFromContext(ctx)})
+}
+
+func (c chainHTTPClient) Put(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, Call{Method: "PUT", Path: url, Params: body, Operation: OperationFromContext(ctx)})
+}
+
+func (c chainHTTPClient) Delete(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, Call{Method: "DELETE", Path: url, Params: queryParams, Operation: OperationFromContext(ctx)})
+}
+
+// APIVersion returns the API version of the wrapped HTTPClient, if it has one.
+func (c chainHTTPClient) APIVersion() string {
+	if versioned, ok := c.httpClient.(interface{ APIVersion() string }); ok {
+		return versioned.APIVersion()
+	}
+	return ""
+}
+
+// ResponseInfo describes the response to a request made by the IntercomHTTPClient,
+// for middleware that needs more than the response body.
+type ResponseInfo struct {
+	StatusCode int
+	RequestID  string
+	// Retries is the number of times the request was retried.
+	Retries int
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a context in which the IntercomHTTPClient records the response to info.
+func WithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+// recordResponseInfo records the response to the ResponseInfo in the context, if there is one.
+func recordResponseInfo(ctx context.Context, retries int, resp *http.Response, err error) {
+	info, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	if !ok {
+		return
+	}
+	*info = ResponseInfo{Retries: retries}
+	var herr HTTPError
+	switch {
+	case errors.As(err, &herr):
+		info.StatusCode = herr.StatusCode
+		info.RequestID = herr.RequestID
+	case resp != nil:
+		info.StatusCode = resp.StatusCode
+		info.RequestID = resp.Header.Get("X-Request-Id")
+	}
+}
@@ -0,0 +1,139 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+)
+
+type recordingHTTPClient struct {
+	calls *[]Call
+}
+
+func (c recordingHTTPClient) Get(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	*c.calls = append(*c.calls, Call{Method: "GET", Path: url, Params: queryParams})
+	return []byte("get"), nil
+}
+
+func (c recordingHTTPClient) Post(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	*c.calls = append(*c.calls, Call{Method: "POST", Path: url, Params: body})
+	return []byte("post"), nil
+}
+
+func (c recordingHTTPClient) Patch(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	*c.calls = append(*c.calls, Call{Method: "PATCH", Path: url, Params: body})
+	return []byte("patch"), nil
+}
+
+func (c recordingHTTPClient) Put(ctx context.Context, url string, body interface{}) ([]byte, error) {
+	*c.calls = append(*c.calls, Call{Method: "PUT", Path: url, Params: body})
+	return []byte("put"), nil
+}
+
+func (c recordingHTTPClient) Delete(ctx context.Context, url string, queryParams interface{}) ([]byte, error) {
+	*c.calls = append(*c.calls, Call{Method: "DELETE", Path: url, Params: queryParams})
+	return []byte("delete"), nil
+}
+
+func (c recordingHTTPClient) APIVersion() string {
+	return "2.0"
+}
+
+func tracing(name string, trace *[]string) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			*trace = append(*trace, name+" "+call.Method+" "+call.Path)
+			return next(ctx, call)
+		}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	calls, trace := []Call{}, []string{}
+	client := Chain(recordingHTTPClient{&calls}, tracing("outer", &trace), tracing("inner", &trace))
+	data, _ := client.Delete(context.Background(), "/tags/1", nil)
+	if string(data) != "delete" || len(calls) != 1 || calls[0].Method != "DELETE" {
+		t.Errorf("Response was %s, calls were %+v", data, calls)
+	}
+	if len(trace) != 2 || trace[0] != "outer DELETE /tags/1" || trace[1] != "inner DELETE /tags/1" {
+		t.Errorf("Trace was %v", trace)
+	}
+}
+
+func TestChainChangesCall(t *testing.T) {
+	calls := []Call{}
+	rewrite := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			call.Path = "/v2" + call.Path
+			return next(ctx, call)
+		}
+	}
+	client := Chain(recordingHTTPClient{&calls}, rewrite)
+	client.Post(context.Background(), "/users", "body")
+	if len(calls) != 1 || calls[0].Path != "/v2/users" || calls[0].Params != "body" {
+		t.Errorf("Calls were %+v", calls)
+	}
+}
+
+func TestChainOperation(t *testing.T) {
+	operations := []Operation{}
+	recording := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			operations = append(operations, call.Operation)
+			return next(ctx, call)
+		}
+	}
+	client := Chain(recordingHTTPClient{&[]Call{}}, recording)
+	client.Get(WithOperation(context.Background(), "users", "find"), "/users/1", nil)
+	client.Get(context.Background(), "/users", nil)
+	if len(operations) != 2 || operations[0].String() != "users.find" || operations[1] != (Operation{}) {
+		t.Errorf("Operations were %+v", operations)
+	}
+}
+
+func TestPutWithoutPutter(t *testing.T) {
+	calls := []Call{}
+	httpClient := struct{ HTTPClient }{recordingHTTPClient{&calls}}
+	if _, err := Put(context.Background(), httpClient, "/admins/1/away", nil); err == nil {
+		t.Errorf("PUT with an HTTPClient that is not a Putter did not fail")
+	}
+	chained := Chain(httpClient, tracing("", &[]string{}))
+	if _, err := chained.(Putter).Put(context.Background(), "/admins/1/away", nil); err == nil || len(calls) != 0 {
+		t.Errorf("Chained PUT error was %v, calls were %+v", err, calls)
+	}
+	if data, err := Put(context.Background(), recordingHTTPClient{&calls}, "/admins/1/away", nil); err != nil || len(calls) != 1 || calls[0].Method != "PUT" {
+		t.Errorf("PUT returned %s, %v, calls were %+v", data, err, calls)
+	}
+}
+
+func TestChainShortCircuit(t *testing.T) {
+	calls := []Call{}
+	cached := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call Call) ([]byte, error) {
+			return []byte("cached"), nil
+		}
+	}
+	client := Chain(recordingHTTPClient{&calls}, cached)
+	if data, _ := client.Get(context.Background(), "/users", nil); string(data) != "cached" || len(calls) != 0 {
+		t.Errorf("Response was %s, calls were %+v", data, calls)
+	}
+}
+
+func TestChainKeepsAPIVersion(t *testing.T) {
+	calls := []Call{}
+	client := Chain(recordingHTTPClient{&calls}, tracing("", &[]string{}))
+	if versioned, ok := client.(interface{ APIVersion() string }); !ok || versioned.APIVersion() != "2.0" {
+		t.Errorf("Chained client did not report the API version")
+	}
+}
+
+func TestResponseInfo(t *testing.T) {
+	calls := 0
+	server := failingServer(1, 503, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	info := ResponseInfo{}
+	client.Get(WithResponseInfo(context.Background(), &info), "/users", nil)
+	if info.StatusCode != 200 || info.Retries != 1 {
+		t.Errorf("ResponseInfo was %+v", info)
+	}
+}
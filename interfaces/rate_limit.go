@@ -0,0 +1,71 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the rate limit window reported by the API's X-RateLimit-* headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimiter tracks the current RateLimit from responses, and can optionally
+// hold back requests until the window resets once it has been exhausted.
+// A RateLimiter is safe for use by multiple goroutines.
+type RateLimiter struct {
+	mu      sync.Mutex
+	current RateLimit
+	wait    bool
+}
+
+// RateLimit returns the most recently seen RateLimit.
+// The zero RateLimit is returned if no response has reported one yet.
+func (r *RateLimiter) RateLimit() RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// SetWait sets whether requests should block until the window resets
+// once no requests remain in it.
+func (r *RateLimiter) SetWait(wait bool) (previous bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, r.wait = r.wait, wait
+	return previous
+}
+
+func (r *RateLimiter) update(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = RateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+}
+
+// untilReset is how long a request must wait before being sent.
+func (r *RateLimiter) untilReset() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.wait || r.current.Limit == 0 || r.current.Remaining > 0 {
+		return 0
+	}
+	return time.Until(r.current.Reset)
+}
+
+func (r *RateLimiter) waitForReset(ctx context.Context) error {
+	if d := r.untilReset(); d > 0 {
+		return sleepContext(ctx, d)
+	}
+	return nil
+}
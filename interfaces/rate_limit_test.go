@@ -0,0 +1,91 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func rateLimitedServer(remaining int, reset time.Time, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("X-RateLimit-Limit", "500")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestRateLimitTracksHeaders(t *testing.T) {
+	calls := 0
+	reset := time.Unix(1500000000, 0)
+	server := rateLimitedServer(42, reset, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	client.RateLimiter = &RateLimiter{}
+	client.Get(context.Background(), "/users", nil)
+	rateLimit := client.RateLimiter.RateLimit()
+	if rateLimit.Limit != 500 {
+		t.Errorf("Limit was %d, expected 500", rateLimit.Limit)
+	}
+	if rateLimit.Remaining != 42 {
+		t.Errorf("Remaining was %d, expected 42", rateLimit.Remaining)
+	}
+	if !rateLimit.Reset.Equal(reset) {
+		t.Errorf("Reset was %s, expected %s", rateLimit.Reset, reset)
+	}
+}
+
+func TestRateLimitIgnoresMissingHeaders(t *testing.T) {
+	limiter := &RateLimiter{current: RateLimit{Limit: 500, Remaining: 10}}
+	limiter.update(http.Header{})
+	if limiter.RateLimit().Remaining != 10 {
+		t.Errorf("Remaining was %d, expected 10", limiter.RateLimit().Remaining)
+	}
+}
+
+func TestRateLimitDoesNotWaitByDefault(t *testing.T) {
+	limiter := &RateLimiter{current: RateLimit{Limit: 500, Remaining: 0, Reset: time.Now().Add(time.Hour)}}
+	if d := limiter.untilReset(); d != 0 {
+		t.Errorf("Wait was %s, expected none", d)
+	}
+}
+
+func TestRateLimitWaitsUntilReset(t *testing.T) {
+	limiter := &RateLimiter{current: RateLimit{Limit: 500, Remaining: 0, Reset: time.Now().Add(time.Hour)}}
+	limiter.SetWait(true)
+	if d := limiter.untilReset(); d < 59*time.Minute {
+		t.Errorf("Wait was %s, expected about an hour", d)
+	}
+	limiter.current.Remaining = 1
+	if d := limiter.untilReset(); d != 0 {
+		t.Errorf("Wait was %s, expected none with requests remaining", d)
+	}
+	limiter.current = RateLimit{Limit: 500, Remaining: 0, Reset: time.Now().Add(-time.Second)}
+	if d := limiter.untilReset(); d > 0 {
+		t.Errorf("Wait was %s, expected none after reset", d)
+	}
+}
+
+func TestRateLimitWaitRespectsContext(t *testing.T) {
+	calls := 0
+	server := rateLimitedServer(0, time.Now().Add(time.Hour), &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	client.RateLimiter = &RateLimiter{}
+	client.RateLimiter.SetWait(true)
+	client.Get(context.Background(), "/users", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, "/users", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Error was %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("Calls was %d, expected 1", calls)
+	}
+}
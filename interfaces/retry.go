@@ -0,0 +1,104 @@
+package interfaces
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy determines how IntercomHTTPClient retries failed requests.
+// The zero value makes a single attempt and never retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubling on each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of each delay that is randomised.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes that trigger a retry.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent allows POSTs that create new resources
+	// (such as /messages) to be retried, which may cause duplicates.
+	RetryNonIdempotent bool
+}
+
+// idempotentPostPaths are POST endpoints that update or create-or-update,
+// so repeating them is safe.
+var idempotentPostPaths = map[string]bool{
+	"/users":     true,
+	"/companies": true,
+	"/tags":      true,
+	// Redacting a part again leaves it redacted.
+	"/conversations/redact": true,
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most callers:
+// 3 attempts with backoff from 500ms to 10s, retrying on 429, 502, 503 and 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Jitter:         0.5,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p RetryPolicy) shouldRetry(req *http.Request, path string, attempt, statusCode int, err error) bool {
+	if attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method, path) {
+		return false
+	}
+	if statusCode == 0 {
+		return err != nil // transport error, such as a connection reset
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff is the delay before the given retry, attempt being the attempt that just failed.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isIdempotent(method, path string) bool {
+	if method != "POST" {
+		return true
+	}
+	return idempotentPostPaths[path]
+}
@@ -0,0 +1,167 @@
+package interfaces
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestIntercomHTTPClient(server *httptest.Server, policy *RetryPolicy) IntercomHTTPClient {
+	baseURI, version, debug := server.URL, "test", false
+	client := NewIntercomHTTPClient("appID", "apiKey", &baseURI, &version, &debug)
+	client.RetryPolicy = policy
+	return client
+}
+
+func failingServer(failures int, statusCode int, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			w.WriteHeader(statusCode)
+			return
+		}
+		w.Write([]byte(`{"type":"user"}`))
+	}))
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	return &policy
+}
+
+func TestRetryOnRetryableStatus(t *testing.T) {
+	calls := 0
+	server := failingServer(2, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	data, err := client.Get(context.Background(), "/users", nil)
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if string(data) != `{"type":"user"}` {
+		t.Errorf("Data was %s", data)
+	}
+	if calls != 3 {
+		t.Errorf("Calls was %d, expected 3", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	server := failingServer(5, http.StatusTooManyRequests, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	_, err := client.Get(context.Background(), "/users", nil)
+	if herr, ok := err.(IntercomError); !ok || herr.GetStatusCode() != http.StatusTooManyRequests {
+		t.Errorf("Error was %v, expected 429", err)
+	}
+	if calls != 3 {
+		t.Errorf("Calls was %d, expected 3", calls)
+	}
+}
+
+func TestNoRetryOnNonRetryableStatus(t *testing.T) {
+	calls := 0
+	server := failingServer(1, http.StatusNotFound, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	client.Get(context.Background(), "/users/1", nil)
+	if calls != 1 {
+		t.Errorf("Calls was %d, expected 1", calls)
+	}
+}
+
+func TestNoRetryWithoutPolicy(t *testing.T) {
+	calls := 0
+	server := failingServer(1, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Get(context.Background(), "/users", nil)
+	if calls != 1 {
+		t.Errorf("Calls was %d, expected 1", calls)
+	}
+}
+
+func TestNoRetryForNonIdempotentPost(t *testing.T) {
+	calls := 0
+	server := failingServer(1, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	client.Post(context.Background(), "/messages", map[string]string{"body": "hi"})
+	if calls != 1 {
+		t.Errorf("Calls was %d, expected 1", calls)
+	}
+}
+
+func TestRetryForNonIdempotentPostWhenAllowed(t *testing.T) {
+	calls := 0
+	server := failingServer(1, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client := newTestIntercomHTTPClient(server, policy)
+	if _, err := client.Post(context.Background(), "/messages", map[string]string{"body": "hi"}); err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if calls != 2 {
+		t.Errorf("Calls was %d, expected 2", calls)
+	}
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "{\"user_id\":\"27\"}\n" {
+			t.Errorf("Body was %q on attempt %d", body, calls)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, testRetryPolicy())
+	client.Post(context.Background(), "/users", map[string]string{"user_id": "27"})
+	if calls != 2 {
+		t.Errorf("Calls was %d, expected 2", calls)
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	calls := 0
+	server := failingServer(5, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+	policy := testRetryPolicy()
+	policy.InitialBackoff = time.Hour
+	client := newTestIntercomHTTPClient(server, policy)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, "/users", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Error was %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("Calls was %d, expected 1", calls)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("Backoff for attempt %d was %s, expected %s", i+1, got, want)
+		}
+	}
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		if got := policy.backoff(1); got < 500*time.Millisecond || got > time.Second {
+			t.Errorf("Jittered backoff was %s, expected between 500ms and 1s", got)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+// A TokenSource supplies the OAuth access token sent as a Bearer token with each request.
+// Implementations that refresh or rotate tokens must be safe for use by multiple goroutines.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token.
+type StaticTokenSource string
+
+// Token returns the token.
+func (s StaticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
@@ -0,0 +1,59 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func authorizationServer(authorization *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+	}))
+}
+
+func TestBasicAuthWithoutTokenSource(t *testing.T) {
+	authorization := ""
+	server := authorizationServer(&authorization)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Get(context.Background(), "/users", nil)
+	if authorization != "Basic YXBwSUQ6YXBpS2V5" {
+		t.Errorf("Authorization was %s, expected Basic auth", authorization)
+	}
+}
+
+func TestBearerAuthWithTokenSource(t *testing.T) {
+	authorization := ""
+	server := authorizationServer(&authorization)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	var tokenSource TokenSource = StaticTokenSource("abc123")
+	client.TokenSource = &tokenSource
+	client.Post(context.Background(), "/users", nil)
+	if authorization != "Bearer abc123" {
+		t.Errorf("Authorization was %s, expected Bearer abc123", authorization)
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	authorization := ""
+	server := authorizationServer(&authorization)
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	var tokenSource TokenSource = failingTokenSource{}
+	client.TokenSource = &tokenSource
+	if _, err := client.Get(context.Background(), "/users", nil); err != errTokenSource {
+		t.Errorf("Error was %v, expected %v", err, errTokenSource)
+	}
+}
+
+var errTokenSource = errors.New("token expired")
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (string, error) {
+	return "", errTokenSource
+}
@@ -0,0 +1,64 @@
+package interfaces
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnstableVersion is Intercom's preview API version, newer than any numbered version.
+const UnstableVersion = "Unstable"
+
+// VersionError is returned when a request needs a newer API version than the one configured.
+type VersionError struct {
+	Method     string
+	Path       string
+	Required   string
+	Configured string
+}
+
+func (e VersionError) Error() string {
+	return fmt.Sprintf("%s %s requires Intercom-Version %s or newer, but %s is configured", e.Method, e.Path, e.Required, e.Configured)
+}
+
+// RequireVersion checks that the configured API version is at least the required version.
+// No check is made if the configured version is empty, as the workspace default is unknown.
+func RequireVersion(configured, required, method, path string) error {
+	if configured == "" || CompareVersions(configured, required) >= 0 {
+		return nil
+	}
+	return VersionError{Method: method, Path: path, Required: required, Configured: configured}
+}
+
+// CompareVersions compares API versions such as "1.4" and "2.10", returning
+// -1 if a is older than b, 0 if they are the same, and +1 if a is newer than b.
+func CompareVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == UnstableVersion {
+		return 1
+	}
+	if b == UnstableVersion {
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		an, bn := versionPart(as, i), versionPart(bs, i)
+		if an < bn {
+			return -1
+		}
+		if an > bn {
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	n, _ := strconv.Atoi(parts[i])
+	return n
+}
@@ -0,0 +1,59 @@
+package interfaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.4", "1.4", 0},
+		{"1.4", "2.0", -1},
+		{"2.10", "2.9", 1},
+		{"2", "2.0", 0},
+		{"Unstable", "2.10", 1},
+		{"2.10", "Unstable", -1},
+	}
+	for _, c := range cases {
+		if got := CompareVersions(c.a, c.b); got != c.expected {
+			t.Errorf("CompareVersions(%s, %s) was %d, expected %d", c.a, c.b, got, c.expected)
+		}
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	if err := RequireVersion("", "2.0", "POST", "/conversations/search"); err != nil {
+		t.Errorf("Error was %v, expected none with no configured version", err)
+	}
+	if err := RequireVersion("2.1", "2.0", "POST", "/conversations/search"); err != nil {
+		t.Errorf("Error was %v, expected none with a newer version", err)
+	}
+	err := RequireVersion("1.4", "2.0", "POST", "/conversations/search")
+	if verr, ok := err.(VersionError); !ok || verr.Required != "2.0" || verr.Configured != "1.4" {
+		t.Errorf("Error was %v, expected a VersionError", err)
+	}
+}
+
+func TestVersionHeader(t *testing.T) {
+	header := "unset"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Intercom-Version")
+	}))
+	defer server.Close()
+	client := newTestIntercomHTTPClient(server, nil)
+	client.Get(context.Background(), "/users", nil)
+	if header != "" {
+		t.Errorf("Intercom-Version was %s, expected none", header)
+	}
+	version := "1.4"
+	client.Version = &version
+	client.Post(context.Background(), "/users", nil)
+	if header != "1.4" {
+		t.Errorf("Intercom-Version was %s, expected 1.4", header)
+	}
+}
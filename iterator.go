@@ -0,0 +1,103 @@
+package intercom
+
+import "context"
+
+// An Iterator walks every item of a paged or scrolled list, fetching
+// further pages from the API as required.
+//
+//  it := ic.Users.IterateList(ctx, intercom.PageParams{PerPage: 50})
+//  for it.Next() {
+//    user := it.Value()
+//  }
+//  if err := it.Err(); err != nil {
+//    ...
+//  }
+//
+// Iteration stops at the first error, or once the context is done.
+// It is safe to stop calling Next early; no further pages are fetched.
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   func(context.Context) ([]T, bool, error)
+	items   []T
+	current T
+	more    bool
+	err     error
+}
+
+func newIterator[T any](ctx context.Context, fetch func(context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, more: true}
+}
+
+// newPageIterator walks pages of a list from params.Page until the last page is reached.
+func newPageIterator[T any](ctx context.Context, params PageParams, list func(context.Context, PageParams) ([]T, PageParams, error)) *Iterator[T] {
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, pages, err := list(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+		// Advance from the page requested, not the page echoed, so a server repeating a page cannot loop forever.
+		more := params.Page < pages.TotalPages
+		params.Page++
+		// Lists with a cursor, such as those backed by search, continue from it.
+		params.Next = pages.Next
+		return items, more, nil
+	})
+}
+
+// newScrollIterator walks a Scroll API list until an empty page is returned.
+func newScrollIterator[T any](ctx context.Context, scroll func(context.Context, string) ([]T, string, error)) *Iterator[T] {
+	scrollParam := ""
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, next, err := scroll(ctx, scrollParam)
+		if err != nil {
+			return nil, false, err
+		}
+		scrollParam = next
+		return items, len(items) > 0 && next != "", nil
+	})
+}
+
+// newCursorIterator walks a cursor paginated list, such as search results, until no next cursor is returned.
+func newCursorIterator[T any](ctx context.Context, list func(context.Context, string) ([]T, string, error)) *Iterator[T] {
+	cursor := ""
+	return newIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		items, next, err := list(ctx, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		cursor = next
+		return items, next != "", nil
+	})
+}
+
+// Next advances to the next item, which is then available through Value.
+// It returns false when there are no more items, or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if !it.more || it.err != nil {
+			return false
+		}
+		if it.err = it.ctx.Err(); it.err != nil {
+			return false
+		}
+		it.items, it.more, it.err = it.fetch(it.ctx)
+	}
+	if it.err = it.ctx.Err(); it.err != nil {
+		return false
+	}
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,152 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIterateListWalksAllPages(t *testing.T) {
+	api := &TestPagedUserAPI{totalPages: 3}
+	it := (&UserService{Repository: api}).IterateList(context.Background(), PageParams{})
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if it.Err() != nil {
+		t.Errorf("Err was %v, expected none", it.Err())
+	}
+	if fmt.Sprint(ids) != "[1a 1b 2a 2b 3a 3b]" {
+		t.Errorf("IDs were %v", ids)
+	}
+	if fmt.Sprint(api.requestedPages) != "[1 2 3]" {
+		t.Errorf("Requested pages were %v, expected [1 2 3]", api.requestedPages)
+	}
+}
+
+func TestIterateListStartsFromPage(t *testing.T) {
+	api := &TestPagedUserAPI{totalPages: 3}
+	it := (&UserService{Repository: api}).IterateList(context.Background(), PageParams{Page: 2})
+	for it.Next() {
+	}
+	if fmt.Sprint(api.requestedPages) != "[2 3]" {
+		t.Errorf("Requested pages were %v, expected [2 3]", api.requestedPages)
+	}
+}
+
+func TestIterateListIgnoresRepeatedPage(t *testing.T) {
+	api := &TestRepeatingPageUserAPI{TestPagedUserAPI: TestPagedUserAPI{totalPages: 3}}
+	it := (&UserService{Repository: api}).IterateList(context.Background(), PageParams{})
+	for it.Next() {
+	}
+	if fmt.Sprint(api.requestedPages) != "[1 2 3]" {
+		t.Errorf("Requested pages were %v, expected [1 2 3]", api.requestedPages)
+	}
+}
+
+func TestIterateListStopsOnError(t *testing.T) {
+	api := &TestPagedUserAPI{totalPages: 3, failOnPage: 2}
+	it := (&UserService{Repository: api}).IterateList(context.Background(), PageParams{})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Iterated %d users, expected 2", count)
+	}
+	if it.Err() != errTestPage {
+		t.Errorf("Err was %v, expected %v", it.Err(), errTestPage)
+	}
+	if it.Next() {
+		t.Errorf("Next returned true after an error")
+	}
+}
+
+func TestIterateListStopsOnContextCancel(t *testing.T) {
+	api := &TestPagedUserAPI{totalPages: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	it := (&UserService{Repository: api}).IterateList(ctx, PageParams{})
+	it.Next()
+	cancel()
+	if it.Next() {
+		t.Errorf("Next returned true after context was cancelled")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Err was %v, expected %v", it.Err(), context.Canceled)
+	}
+	if len(api.requestedPages) != 1 {
+		t.Errorf("Requested pages were %v, expected just the first", api.requestedPages)
+	}
+}
+
+func TestIterateScrollWalksUntilEmpty(t *testing.T) {
+	api := &TestPagedUserAPI{totalPages: 2}
+	it := (&UserService{Repository: api}).IterateScroll(context.Background())
+	ids := []string{}
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if it.Err() != nil {
+		t.Errorf("Err was %v, expected none", it.Err())
+	}
+	if fmt.Sprint(ids) != "[1a 1b 2a 2b]" {
+		t.Errorf("IDs were %v", ids)
+	}
+	if fmt.Sprint(api.scrollParams) != "[ scroll-1 scroll-2]" {
+		t.Errorf("Scroll params were %q", api.scrollParams)
+	}
+}
+
+var errTestPage = errors.New("page error")
+
+type TestPagedUserAPI struct {
+	totalPages     int64
+	failOnPage     int64
+	requestedPages []int64
+	scrollParams   []string
+}
+
+func (t *TestPagedUserAPI) page(page int64) []User {
+	if page > t.totalPages {
+		return []User{}
+	}
+	return []User{{ID: fmt.Sprintf("%da", page)}, {ID: fmt.Sprintf("%db", page)}}
+}
+
+func (t *TestPagedUserAPI) list(ctx context.Context, params userListParams) (UserList, error) {
+	t.requestedPages = append(t.requestedPages, params.Page)
+	if params.Page == t.failOnPage {
+		return UserList{}, errTestPage
+	}
+	return UserList{Users: t.page(params.Page), Pages: PageParams{Page: params.Page, TotalPages: t.totalPages}}, nil
+}
+
+// TestRepeatingPageUserAPI always reports its page as the first.
+type TestRepeatingPageUserAPI struct {
+	TestPagedUserAPI
+}
+
+func (t *TestRepeatingPageUserAPI) list(ctx context.Context, params userListParams) (UserList, error) {
+	userList, err := t.TestPagedUserAPI.list(ctx, params)
+	userList.Pages.Page = 1
+	return userList, err
+}
+
+func (t *TestPagedUserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
+	t.scrollParams = append(t.scrollParams, scrollParam)
+	page := int64(len(t.scrollParams))
+	return UserList{Users: t.page(page), ScrollParam: fmt.Sprintf("scroll-%d", page)}, nil
+}
+
+func (t *TestPagedUserAPI) find(ctx context.Context, params UserIdentifiers) (User, error) {
+	return User{}, nil
+}
+
+func (t *TestPagedUserAPI) save(ctx context.Context, user *User) (User, error) {
+	return User{}, nil
+}
+
+func (t *TestPagedUserAPI) delete(ctx context.Context, id string) (User, error) {
+	return User{}, nil
+}
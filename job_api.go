@@ -20,6 +20,7 @@ type JobAPI struct {
 }
 
 func (api JobAPI) save(ctx context.Context, job *JobRequest) (JobResponse, error) {
+	ctx = interfaces.WithOperation(ctx, "jobs", "save")
 	for i := range job.Items {
 		obj := job.Items[i].Data
 		switch obj.(type) {
@@ -38,6 +39,7 @@ func (api JobAPI) save(ctx context.Context, job *JobRequest) (JobResponse, error
 }
 
 func (api JobAPI) find(ctx context.Context, id string) (JobResponse, error) {
+	ctx = interfaces.WithOperation(ctx, "jobs", "find")
 	fetchedJob := JobResponse{}
 	data, err := api.httpClient.Get(ctx, fmt.Sprintf("/jobs/%s", id), nil)
 	if err != nil {
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -18,7 +19,7 @@ func TestJobAPISaveUser(t *testing.T) {
 			t.Errorf("wrong user id sent")
 		}
 	}
-	savedJob, _ := api.save(&job)
+	savedJob, _ := api.save(context.Background(), &job)
 	if savedJob.ID != "job_5ca1ab1eca11ab1e" {
 		t.Errorf("Did not respond with correct job")
 	}
@@ -37,7 +38,7 @@ func TestJobAPISaveEvent(t *testing.T) {
 			t.Errorf("wrong user id sent")
 		}
 	}
-	savedJob, _ := api.save(&job)
+	savedJob, _ := api.save(context.Background(), &job)
 	if savedJob.ID != "job_5ca1ab1eca11ab1e" {
 		t.Errorf("Did not respond with correct job")
 	}
@@ -51,7 +52,7 @@ type TestJobHTTPClient struct {
 	expectedURI     string
 }
 
-func (t *TestJobHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+func (t *TestJobHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("Wrong endpoint called")
 	}
@@ -1,6 +1,9 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestNewJob(t *testing.T) {
 	repo := &TestJobRepository{t: t}
@@ -15,13 +18,13 @@ func TestNewJob(t *testing.T) {
 	}
 	user := User{Email: "foo@bar.com"}
 	js := JobService{Repository: repo}
-	js.NewUserJob(NewUserJobItem(&user, JOB_POST))
+	js.NewUserJob(context.Background(), NewUserJobItem(&user, JOB_POST))
 }
 
 func TestAppendJob(t *testing.T) {
 	repo := &TestJobRepository{t: t}
 	js := JobService{Repository: repo}
-	newJob, _ := js.NewUserJob()
+	newJob, _ := js.NewUserJob(context.Background())
 
 	repo.f = func(job *JobRequest) {
 		if job.Items[0].Method != JOB_POST.String() {
@@ -34,7 +37,7 @@ func TestAppendJob(t *testing.T) {
 	}
 	user := User{Email: "foo@bar.com"}
 
-	js.AppendUsers(newJob.ID, NewUserJobItem(&user, JOB_POST))
+	js.AppendUsers(context.Background(), newJob.ID, NewUserJobItem(&user, JOB_POST))
 }
 
 type TestJobRepository struct {
@@ -42,13 +45,13 @@ type TestJobRepository struct {
 	f func(job *JobRequest)
 }
 
-func (api *TestJobRepository) save(job *JobRequest) (JobResponse, error) {
+func (api *TestJobRepository) save(ctx context.Context, job *JobRequest) (JobResponse, error) {
 	if api.f != nil {
 		api.f(job)
 	}
 	return JobResponse{}, nil
 }
 
-func (api *TestJobRepository) find(id string) (JobResponse, error) {
+func (api *TestJobRepository) find(ctx context.Context, id string) (JobResponse, error) {
 	return JobResponse{}, nil
 }
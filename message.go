@@ -64,6 +64,8 @@ type MessageResponse struct {
 	Subject     string          `json:"subject,omitempty"`
 	Body        string          `json:"body,omitempty"`
 	Template    MessageTemplate `json:"template,omitempty"`
+	// ConversationID is the Conversation started by the Message.
+	ConversationID string `json:"conversation_id,omitempty"`
 }
 
 func (m MessageResponse) String() string {
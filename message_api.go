@@ -18,6 +18,7 @@ type MessageAPI struct {
 }
 
 func (api MessageAPI) save(ctx context.Context, message *MessageRequest) (MessageResponse, error) {
+	ctx = interfaces.WithOperation(ctx, "messages", "save")
 	data, err := api.httpClient.Post(ctx, "/messages", message)
 	savedMessage := MessageResponse{}
 	if err != nil {
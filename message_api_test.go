@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -9,7 +10,7 @@ func TestMessageAPISave(t *testing.T) {
 	http := TestMessageHTTPClient{t: t, expectedURI: "/messages", fixtureFilename: "fixtures/message.json"}
 	api := MessageAPI{httpClient: &http}
 	message := NewUserMessage(User{}, "Hey, is the new thing in stock?")
-	msg, err := api.save(&message)
+	msg, err := api.save(context.Background(), &message)
 	if err != nil {
 		t.Error(err)
 	}
@@ -32,7 +33,7 @@ type TestMessageHTTPClient struct {
 	lastQueryParams interface{}
 }
 
-func (t *TestMessageHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+func (t *TestMessageHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("Wrong endpoint called")
 	}
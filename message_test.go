@@ -1,6 +1,9 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestNewEmailMessage(t *testing.T) {
 	user := User{}
@@ -76,7 +79,7 @@ func TestNewUserMessage(t *testing.T) {
 func TestSaveMessage(t *testing.T) {
 	messageService := MessageService{Repository: TestMessageAPI{t: t}}
 	message := NewInAppMessage(Admin{}, User{}, "hi there")
-	resp, _ := messageService.Save(&message)
+	resp, _ := messageService.Save(context.Background(), &message)
 	if resp.Owner.Type != "admin" {
 		t.Errorf("Owner was not admin")
 	}
@@ -86,7 +89,7 @@ type TestMessageAPI struct {
 	t *testing.T
 }
 
-func (t TestMessageAPI) save(message *MessageRequest) (MessageResponse, error) {
+func (t TestMessageAPI) save(ctx context.Context, message *MessageRequest) (MessageResponse, error) {
 	if message.MessageType != "inapp" {
 		t.t.Errorf("Message not inapp")
 	}
@@ -7,17 +7,24 @@ import (
 
 // Notification is the object delivered to a webhook.
 type Notification struct {
-	ID               string        `json:"id,omitempty"`
-	CreatedAt        int64         `json:"created_at,omitempty"`
-	Topic            string        `json:"topic,omitempty"`
-	DeliveryAttempts int64         `json:"delivery_attempts,omitempty"`
-	FirstSentAt      int64         `json:"first_sent_at,omitempty"`
-	RawData          *Data         `json:"data,omitempty"`
-	Conversation     *Conversation `json:"-"`
-	User             *User         `json:"-"`
-	Tag              *Tag          `json:"-"`
-	Company          *Company      `json:"-"`
-	Event            *Event        `json:"-"`
+	ID               string            `json:"id,omitempty"`
+	CreatedAt        int64             `json:"created_at,omitempty"`
+	Topic            string            `json:"topic,omitempty"`
+	DeliveryAttempts int64             `json:"delivery_attempts,omitempty"`
+	FirstSentAt      int64             `json:"first_sent_at,omitempty"`
+	RawData          *Data             `json:"data,omitempty"`
+	Conversation     *Conversation     `json:"-"`
+	ConversationPart *ConversationPart `json:"-"`
+	User             *User             `json:"-"`
+	Contact          *Contact          `json:"-"`
+	Tag              *Tag              `json:"-"`
+	TaggedObject     *TaggedObject     `json:"-"`
+	Company          *Company          `json:"-"`
+	Event            *Event            `json:"-"`
+
+	// UnknownTopic is set when the Topic is not one this package knows how to parse.
+	// Only RawData is available for these Notifications.
+	UnknownTopic bool `json:"-"`
 }
 
 // Data is the data node of the notification.
@@ -25,9 +32,22 @@ type Data struct {
 	Item json.RawMessage `json:"item,omitempty"`
 }
 
+// TaggedObject is delivered when a Tag is added to or removed from an object,
+// such as a User, Contact or ConversationPart.
+type TaggedObject struct {
+	Type             string            `json:"type,omitempty"`
+	CreatedAt        int64             `json:"created_at,omitempty"`
+	Tag              *Tag              `json:"tag,omitempty"`
+	User             *User             `json:"user,omitempty"`
+	Contact          *Contact          `json:"contact,omitempty"`
+	Company          *Company          `json:"company,omitempty"`
+	ConversationPart *ConversationPart `json:"conversation_part,omitempty"`
+}
+
 // NewNotification parses a Notification from json read from an io.Reader.
 // It may only contain partial objects (such as a single conversation part)
 // depending on what is provided by the webhook.
+// Notifications with a topic that is not recognised have UnknownTopic set.
 func NewNotification(r io.Reader) (*Notification, error) {
 	notification := &Notification{
 		RawData: &Data{},
@@ -36,38 +56,79 @@ func NewNotification(r io.Reader) (*Notification, error) {
 	if err != nil {
 		return nil, err
 	}
+	item := notification.RawData.Item
 	switch notification.Topic {
 	case "conversation.user.created",
 		"conversation.user.replied",
 		"conversation.admin.replied",
 		"conversation.admin.single.created",
 		"conversation.admin.assigned",
+		"conversation.admin.open.assigned",
 		"conversation.admin.noted",
 		"conversation.admin.closed",
-		"conversation.admin.opened":
+		"conversation.admin.opened",
+		"conversation.admin.snoozed",
+		"conversation.admin.unsnoozed":
 		c := &Conversation{}
-		json.Unmarshal(notification.RawData.Item, c)
+		err = json.Unmarshal(item, c)
 		notification.Conversation = c
 	case "user.created",
 		"user.deleted",
 		"user.unsubscribed",
-		"user.email.updated":
+		"user.email.updated",
+		"contact.signed_up",
+		"visitor.signed_up":
 		u := &User{}
-		json.Unmarshal(notification.RawData.Item, u)
+		err = json.Unmarshal(item, u)
 		notification.User = u
+	case "contact.created",
+		"contact.added_email":
+		c := &Contact{}
+		err = json.Unmarshal(item, c)
+		notification.Contact = c
 	case "user.tag.created",
-		"user.tag.deleted":
-		t := &Tag{}
-		json.Unmarshal(notification.RawData.Item, t)
-		notification.Tag = t
-	case "company.created":
+		"user.tag.deleted",
+		"contact.tag.created",
+		"contact.tag.deleted",
+		"conversation_part.tag.created":
+		err = notification.parseTaggedObject(item)
+	case "company.created",
+		"company.updated":
 		c := &Company{}
-		json.Unmarshal(notification.RawData.Item, c)
+		err = json.Unmarshal(item, c)
 		notification.Company = c
 	case "event.created":
 		e := &Event{}
-		json.Unmarshal(notification.RawData.Item, e)
+		err = json.Unmarshal(item, e)
 		notification.Event = e
+	case "ping":
+	default:
+		notification.UnknownTopic = true
+	}
+	if err != nil {
+		return nil, err
 	}
 	return notification, nil
 }
+
+// parseTaggedObject parses the Tag and the object it was applied to.
+// Older payloads are just the Tag itself.
+func (n *Notification) parseTaggedObject(item json.RawMessage) error {
+	t := &TaggedObject{}
+	if err := json.Unmarshal(item, t); err != nil {
+		return err
+	}
+	if t.Tag == nil {
+		t.Tag = &Tag{}
+		if err := json.Unmarshal(item, t.Tag); err != nil {
+			return err
+		}
+	}
+	n.TaggedObject = t
+	n.Tag = t.Tag
+	n.User = t.User
+	n.Contact = t.Contact
+	n.Company = t.Company
+	n.ConversationPart = t.ConversationPart
+	return nil
+}
@@ -150,3 +150,148 @@ func TestParsingEventFromReader(t *testing.T) {
 		}
 	}
 }
+
+func TestParsingContactFromReader(t *testing.T) {
+	topics := []string{
+		"contact.created",
+		"contact.added_email",
+	}
+
+	for _, topic := range topics {
+		payload, _ := ioutil.ReadFile("fixtures/contact.json")
+		r := strings.NewReader(fmt.Sprintf(`{
+			"topic": "%s",
+			"data": {
+				"item": %s
+			}
+		}`, topic, string(payload)))
+		n, _ := NewNotification(r)
+		if n.Contact == nil || n.Contact.ID != "54c42e7ea7a765fa7" {
+			t.Errorf("Notification did not have Contact for %s", topic)
+		}
+	}
+}
+
+func TestParsingSignedUpUserFromReader(t *testing.T) {
+	topics := []string{
+		"contact.signed_up",
+		"visitor.signed_up",
+	}
+
+	for _, topic := range topics {
+		payload, _ := ioutil.ReadFile("fixtures/user.json")
+		r := strings.NewReader(fmt.Sprintf(`{
+			"topic": "%s",
+			"data": {
+				"item": %s
+			}
+		}`, topic, string(payload)))
+		n, _ := NewNotification(r)
+		if n.User == nil {
+			t.Errorf("Notification did not have User for %s", topic)
+		}
+	}
+}
+
+func TestParsingSnoozedConversationFromReader(t *testing.T) {
+	topics := []string{
+		"conversation.admin.snoozed",
+		"conversation.admin.unsnoozed",
+	}
+
+	for _, topic := range topics {
+		payload, _ := ioutil.ReadFile("fixtures/conversation.json")
+		r := strings.NewReader(fmt.Sprintf(`{
+			"topic": "%s",
+			"data": {
+				"item": %s
+			}
+		}`, topic, string(payload)))
+		n, _ := NewNotification(r)
+		if n.Conversation == nil {
+			t.Errorf("Notification did not have Conversation for %s", topic)
+		}
+	}
+}
+
+func TestParsingUpdatedCompanyFromReader(t *testing.T) {
+	r := strings.NewReader(`{"topic": "company.updated", "data": {"item": {"type": "company", "id": "531ee472cce572a6ec000006", "name": "Blue Sun"}}}`)
+	n, _ := NewNotification(r)
+	if n.Company == nil || n.Company.Name != "Blue Sun" {
+		t.Errorf("Notification did not have Company")
+	}
+}
+
+func TestParsingTaggedUserFromReader(t *testing.T) {
+	r := strings.NewReader(`{
+		"topic": "user.tag.deleted",
+		"data": {
+			"item": {
+				"type": "user_tag",
+				"created_at": 1392731331,
+				"tag": {"type": "tag", "id": "60218", "name": "My Tag"},
+				"user": {"type": "user", "id": "54c42e7ea7a765fa7", "email": "myuser@example.io"}
+			}
+		}
+	}`)
+	n, err := NewNotification(r)
+	if err != nil {
+		t.Fatalf("Error was %v, expected none", err)
+	}
+	if n.Tag == nil || n.Tag.ID != "60218" {
+		t.Errorf("Notification did not have Tag")
+	}
+	if n.User == nil || n.User.Email != "myuser@example.io" {
+		t.Errorf("Notification did not have tagged User")
+	}
+	if n.TaggedObject == nil || n.TaggedObject.CreatedAt != 1392731331 {
+		t.Errorf("Notification did not have TaggedObject")
+	}
+}
+
+func TestParsingTaggedConversationPartFromReader(t *testing.T) {
+	r := strings.NewReader(`{
+		"topic": "conversation_part.tag.created",
+		"data": {
+			"item": {
+				"type": "conversation_part_tag",
+				"tag": {"type": "tag", "id": "60218", "name": "My Tag"},
+				"conversation_part": {"type": "conversation_part", "id": "4412", "part_type": "comment", "body": "<p>Hi</p>"}
+			}
+		}
+	}`)
+	n, _ := NewNotification(r)
+	if n.Tag == nil || n.Tag.Name != "My Tag" {
+		t.Errorf("Notification did not have Tag")
+	}
+	if n.ConversationPart == nil || n.ConversationPart.ID != "4412" {
+		t.Errorf("Notification did not have tagged ConversationPart")
+	}
+}
+
+func TestParsingUnknownTopicFromReader(t *testing.T) {
+	r := strings.NewReader(`{"topic": "something.new", "data": {"item": {"type": "something"}}}`)
+	n, err := NewNotification(r)
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if !n.UnknownTopic {
+		t.Errorf("Notification was not marked as UnknownTopic")
+	}
+	if string(n.RawData.Item) != `{"type": "something"}` {
+		t.Errorf("RawData was %s", n.RawData.Item)
+	}
+
+	r = strings.NewReader(`{"topic": "ping", "data": {"item": {"type": "ping"}}}`)
+	if n, _ := NewNotification(r); n.UnknownTopic {
+		t.Errorf("Notification for ping was marked as UnknownTopic")
+	}
+}
+
+func TestParsingItemError(t *testing.T) {
+	r := strings.NewReader(`{"topic": "user.created", "data": {"item": {"id": 12}}}`)
+	_, err := NewNotification(r)
+	if err == nil {
+		t.Errorf("Error not returned for invalid User")
+	}
+}
@@ -0,0 +1,114 @@
+// Package oauth implements Intercom's OAuth flow, exchanging an authorization code
+// for an access token that can be used with intercom.NewClientWithToken.
+//
+//  config := oauth.Config{ClientID: "client_id", ClientSecret: "client_secret"}
+//  http.Redirect(w, r, config.AuthCodeURL(state), http.StatusFound)
+//
+//  // then, in the redirect handler
+//  token, err := config.Exchange(ctx, r.FormValue("code"))
+//  ic := intercom.NewClientWithToken(token.AccessToken)
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+const (
+	// AuthURL is where users are sent to authorize your app.
+	AuthURL = "https://app.intercom.com/oauth"
+	// TokenURL is where authorization codes are exchanged for access tokens.
+	TokenURL = "https://api.intercom.io/auth/eagle/token"
+)
+
+// Config identifies your app to Intercom's OAuth endpoints.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL, if set, overrides the redirect URL configured for your app.
+	RedirectURL string
+
+	// AuthURL and TokenURL override the Intercom endpoints, typically for testing.
+	AuthURL  string
+	TokenURL string
+	// HTTPClient is used to exchange codes, defaulting to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Token is an access token granted to your app.
+type Token struct {
+	Type        string `json:"type,omitempty"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type,omitempty"`
+}
+
+// TokenSource returns a TokenSource for the access token, for use with intercom.SetTokenSource.
+func (t Token) TokenSource() interfaces.TokenSource {
+	return interfaces.StaticTokenSource(t.AccessToken)
+}
+
+type tokenRequest struct {
+	Code         string `json:"code"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// AuthCodeURL returns the URL to send a user to in order to authorize your app.
+// The state is returned to the redirect URL unchanged, and should be checked to prevent CSRF.
+func (c Config) AuthCodeURL(state string) string {
+	v := url.Values{"client_id": {c.ClientID}, "state": {state}}
+	if c.RedirectURL != "" {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+	authURL := c.AuthURL
+	if authURL == "" {
+		authURL = AuthURL
+	}
+	return authURL + "?" + v.Encode()
+}
+
+// Exchange exchanges an authorization code, received at the redirect URL, for a Token.
+// Errors returned by Intercom are an interfaces.HTTPError.
+func (c Config) Exchange(ctx context.Context, code string) (Token, error) {
+	token := Token{}
+	body, err := json.Marshal(tokenRequest{Code: code, ClientID: c.ClientID, ClientSecret: c.ClientSecret})
+	if err != nil {
+		return token, err
+	}
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = TokenURL
+	}
+	req, err := http.NewRequest("POST", tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return token, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return token, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return token, err
+	}
+	if resp.StatusCode >= 400 {
+		return token, interfaces.ParseResponseError(data, resp, req.URL.Path)
+	}
+	err = json.Unmarshal(data, &token)
+	return token, err
+}
@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func tokenServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := tokenRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ClientID != "client_id" || req.ClientSecret != "client_secret" {
+			t.Errorf("Token request was %+v", req)
+		}
+		if req.Code != "good_code" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"type":"error.list","errors":[{"code":"unauthorized","message":"Invalid code"}]}`))
+			return
+		}
+		w.Write([]byte(`{"type":"token","access_token":"abc123","token_type":"Bearer"}`))
+	}))
+}
+
+func TestExchange(t *testing.T) {
+	server := tokenServer(t)
+	defer server.Close()
+	config := Config{ClientID: "client_id", ClientSecret: "client_secret", TokenURL: server.URL}
+	token, err := config.Exchange(context.Background(), "good_code")
+	if err != nil {
+		t.Errorf("Error was %v, expected none", err)
+	}
+	if token.AccessToken != "abc123" || token.TokenType != "Bearer" {
+		t.Errorf("Token was %+v", token)
+	}
+	if accessToken, _ := token.TokenSource().Token(); accessToken != "abc123" {
+		t.Errorf("TokenSource returned %s, expected abc123", accessToken)
+	}
+}
+
+func TestExchangeError(t *testing.T) {
+	server := tokenServer(t)
+	defer server.Close()
+	config := Config{ClientID: "client_id", ClientSecret: "client_secret", TokenURL: server.URL}
+	_, err := config.Exchange(context.Background(), "bad_code")
+	if !errors.Is(err, interfaces.ErrUnauthorized) {
+		t.Errorf("Error was %v, expected %v", err, interfaces.ErrUnauthorized)
+	}
+	if herr, ok := err.(interfaces.HTTPError); !ok || herr.Message != "Invalid code" {
+		t.Errorf("Error was %v, expected Invalid code", err)
+	}
+	herr := err.(interfaces.HTTPError)
+	if herr.Method != "POST" || herr.GetHeader() == nil || herr.GetErrors()[0].StatusCode != http.StatusUnauthorized {
+		t.Errorf("Error was %+v, expected it parsed as by IntercomHTTPClient", herr)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	config := Config{ClientID: "client_id", RedirectURL: "https://example.com/callback"}
+	authURL, _ := url.Parse(config.AuthCodeURL("xyz"))
+	if authURL.Host != "app.intercom.com" || authURL.Path != "/oauth" {
+		t.Errorf("AuthCodeURL was %s", authURL)
+	}
+	query := authURL.Query()
+	if query.Get("client_id") != "client_id" || query.Get("state") != "xyz" || query.Get("redirect_uri") != "https://example.com/callback" {
+		t.Errorf("AuthCodeURL query was %v", query)
+	}
+}
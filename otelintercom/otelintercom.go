@@ -0,0 +1,131 @@
+// Package otelintercom instruments an intercom.Client with OpenTelemetry tracing and metrics.
+//
+// A span is created for each HTTP call, named for the repository operation that made it,
+// such as intercom.users.find or intercom.conversations.reply. An operation making several
+// calls records several spans: saving a User through unified Contacts records
+// intercom.contacts.search then intercom.contacts.update. Latency and errors are recorded
+// per operation:
+//
+//  ic.Option(intercom.Use(otelintercom.Middleware(otelintercom.Config{})))
+//
+// The global TracerProvider and MeterProvider are used unless others are configured.
+package otelintercom
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the Tracer and Meter.
+const ScopeName = "github.com/opensimsim/intercom-go/otelintercom"
+
+// Attribute keys set on spans and measurements.
+const (
+	ResourceKey   = attribute.Key("intercom.resource")
+	OperationKey  = attribute.Key("intercom.operation")
+	ErrorCodeKey  = attribute.Key("intercom.error.code")
+	RequestIDKey  = attribute.Key("intercom.request_id")
+	RetriesKey    = attribute.Key("intercom.retries")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Config configures the instrumentation.
+type Config struct {
+	// TracerProvider defaults to the global TracerProvider.
+	TracerProvider trace.TracerProvider
+	// MeterProvider defaults to the global MeterProvider.
+	MeterProvider metric.MeterProvider
+}
+
+type instruments struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// Middleware returns interfaces.Middleware recording a span, the latency, and any error,
+// for each call. Latency is recorded to the intercom.client.duration histogram, in seconds,
+// and errors are counted by the intercom.client.errors counter.
+func Middleware(config Config) interfaces.Middleware {
+	tracerProvider, meterProvider := config.TracerProvider, config.MeterProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	meter := meterProvider.Meter(ScopeName)
+	i := instruments{tracer: tracerProvider.Tracer(ScopeName)}
+	// Instrument errors are handled by the global ErrorHandler, leaving a no-op instrument.
+	i.duration, _ = meter.Float64Histogram("intercom.client.duration",
+		metric.WithDescription("Duration of Intercom API operations"), metric.WithUnit("s"))
+	i.errors, _ = meter.Int64Counter("intercom.client.errors",
+		metric.WithDescription("Number of failed Intercom API operations"), metric.WithUnit("{error}"))
+
+	return func(next interfaces.RoundTrip) interfaces.RoundTrip {
+		return func(ctx context.Context, call interfaces.Call) ([]byte, error) {
+			return i.roundTrip(ctx, call, next)
+		}
+	}
+}
+
+func (i instruments) roundTrip(ctx context.Context, call interfaces.Call, next interfaces.RoundTrip) ([]byte, error) {
+	resource, action := Operation(call)
+	operation := resource + "." + action
+	attrs := []attribute.KeyValue{ResourceKey.String(resource), OperationKey.String(operation), MethodKey.String(call.Method)}
+	ctx, span := i.tracer.Start(ctx, "intercom."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	info := interfaces.ResponseInfo{}
+	start := time.Now()
+	data, err := next(interfaces.WithResponseInfo(ctx, &info), call)
+	elapsed := time.Since(start)
+
+	var herr interfaces.HTTPError
+	if errors.As(err, &herr) && info.StatusCode == 0 {
+		info.StatusCode, info.RequestID = herr.StatusCode, herr.RequestID
+	}
+	if info.StatusCode != 0 {
+		attrs = append(attrs, StatusCodeKey.Int(info.StatusCode))
+	}
+	if err != nil {
+		errorCode := herr.Code
+		if errorCode == "" {
+			errorCode = "unknown"
+		}
+		attrs = append(attrs, ErrorCodeKey.String(errorCode))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	i.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+
+	span.SetAttributes(attrs[3:]...)
+	if info.RequestID != "" {
+		span.SetAttributes(RequestIDKey.String(info.RequestID))
+	}
+	if info.Retries > 0 {
+		span.SetAttributes(RetriesKey.Int(info.Retries))
+	}
+	return data, err
+}
+
+// Operation names the repository operation a call was made for, such as ("users", "find").
+// Calls made outside a repository are named by the first segment of their path and their method.
+func Operation(call interfaces.Call) (resource, action string) {
+	if call.Operation.Resource != "" {
+		return call.Operation.Resource, call.Operation.Action
+	}
+	resource, _, _ = strings.Cut(strings.TrimPrefix(call.Path, "/"), "/")
+	return resource, strings.ToLower(call.Method)
+}
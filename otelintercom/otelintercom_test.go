@@ -0,0 +1,145 @@
+package otelintercom
+
+import (
+	"context"
+	"testing"
+
+	intercom "github.com/opensimsim/intercom-go"
+	"github.com/opensimsim/intercom-go/intercomtest"
+	"github.com/opensimsim/intercom-go/interfaces"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func instrumentedClient(server *intercomtest.Server) (*intercom.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	ic := server.Client()
+	ic.Option(intercom.Use(Middleware(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})))
+	return ic, spans, reader
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestSpans(t *testing.T) {
+	server := intercomtest.NewServer()
+	defer server.Close()
+	ic, spans, _ := instrumentedClient(server)
+	ctx := context.Background()
+	admin := server.AddAdmin(intercom.Admin{Name: "Jamie"})
+	convo := server.AddConversation(intercom.Conversation{})
+
+	ic.Users.Save(ctx, &intercom.User{UserID: "27"})
+	ic.Users.FindByUserID(ctx, "27")
+	ic.Conversations.Reply(ctx, convo.ID, &admin, intercom.CONVERSATION_COMMENT, "Hi")
+
+	ended := spans.Ended()
+	names := []string{"intercom.users.save", "intercom.users.find", "intercom.conversations.reply"}
+	if len(ended) != len(names) {
+		t.Fatalf("Recorded %d spans, expected %d", len(ended), len(names))
+	}
+	for i, name := range names {
+		if ended[i].Name() != name {
+			t.Errorf("Span was %s, expected %s", ended[i].Name(), name)
+		}
+	}
+	find := ended[1]
+	if spanAttribute(find, ResourceKey).AsString() != "users" || spanAttribute(find, StatusCodeKey).AsInt64() != 200 {
+		t.Errorf("Span attributes were %v", find.Attributes())
+	}
+}
+
+func TestErrorSpanAndMetrics(t *testing.T) {
+	server := intercomtest.NewServer()
+	defer server.Close()
+	ic, spans, reader := instrumentedClient(server)
+	ctx := context.Background()
+
+	ic.Users.FindByUserID(ctx, "27")
+	ic.Admins.List(ctx)
+
+	span := spans.Ended()[0]
+	if span.Status().Code != codes.Error || spanAttribute(span, StatusCodeKey).AsInt64() != 404 || spanAttribute(span, ErrorCodeKey).AsString() != "not_found" {
+		t.Errorf("Span status was %v, attributes %v", span.Status(), span.Attributes())
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Error collecting metrics: %v", err)
+	}
+	durations, errorCounts := map[string]uint64{}, map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					operation, _ := dp.Attributes.Value(OperationKey)
+					durations[operation.AsString()] += dp.Count
+				}
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					operation, _ := dp.Attributes.Value(OperationKey)
+					errorCounts[operation.AsString()] += dp.Value
+				}
+			}
+		}
+	}
+	if durations["users.find"] != 1 || durations["admins.list"] != 1 {
+		t.Errorf("Durations recorded were %v", durations)
+	}
+	if errorCounts["users.find"] != 1 || errorCounts["admins.list"] != 0 {
+		t.Errorf("Errors counted were %v", errorCounts)
+	}
+}
+
+func TestOperation(t *testing.T) {
+	server := intercomtest.NewServer()
+	defer server.Close()
+	ic, spans, _ := instrumentedClient(server)
+	ctx := context.Background()
+	convo := server.AddConversation(intercom.Conversation{})
+	admin := server.AddAdmin(intercom.Admin{Name: "Jamie"})
+
+	ic.Users.FindByEmail(ctx, "a@b.c")
+	ic.Users.List(ctx, intercom.PageParams{})
+	ic.Companies.ListUsersByCompanyID(ctx, "5", intercom.PageParams{})
+	ic.Contacts.Create(ctx, &intercom.Contact{})
+	ic.Contacts.Update(ctx, &intercom.Contact{ID: "1"})
+	ic.Conversations.MarkRead(ctx, convo.ID)
+	ic.Tags.Tag(ctx, &intercom.TaggingList{Name: "a", Users: []intercom.Tagging{{UserID: "1"}}})
+	ic.Counts.UsersBySegment(ctx)
+	ic.Admins.SetAway(ctx, admin.ID.String(), true, false)
+
+	names := []string{"users.find", "users.list", "companies.list_users", "contacts.create", "contacts.update",
+		"conversations.read", "tags.tag", "counts.user_by_segment", "admins.set_away"}
+	ended := spans.Ended()
+	if len(ended) != len(names) {
+		t.Fatalf("Recorded %d spans, expected %d", len(ended), len(names))
+	}
+	for i, name := range names {
+		if ended[i].Name() != "intercom."+name || spanAttribute(ended[i], OperationKey).AsString() != name {
+			t.Errorf("Span was %s, expected intercom.%s", ended[i].Name(), name)
+		}
+	}
+}
+
+func TestOperationWithoutRepository(t *testing.T) {
+	resource, action := Operation(interfaces.Call{Method: "GET", Path: "/users/5/notes"})
+	if resource != "users" || action != "get" {
+		t.Errorf("Operation was %s.%s, expected users.get", resource, action)
+	}
+}
@@ -1,8 +1,29 @@
 package intercom
 
+import "encoding/json"
+
 // PageParams determine paging information to and from the API
 type PageParams struct {
 	Page       int64 `json:"page" url:"page,omitempty"`
 	PerPage    int64 `json:"per_page" url:"per_page,omitempty"`
 	TotalPages int64 `json:"total_pages" url:"-"`
+	// Next points to the next page, if there is one.
+	Next *PageCursor `json:"next,omitempty" url:"-"`
+}
+
+// PageCursor points to the next page of a list. Cursor paginated lists, such as search
+// results, set StartingAfter; page numbered lists set URL.
+type PageCursor struct {
+	Page          int64  `json:"page,omitempty"`
+	StartingAfter string `json:"starting_after,omitempty"`
+	URL           string `json:"-"`
+}
+
+// UnmarshalJSON accepts both the cursor object and the URL given by page numbered lists.
+func (c *PageCursor) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.URL)
+	}
+	type cursor PageCursor
+	return json.Unmarshal(data, (*cursor)(c))
 }
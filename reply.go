@@ -11,6 +11,7 @@ type Reply struct {
 	Email          string   `json:"email,omitempty"`
 	UserID         string   `json:"user_id,omitempty"`
 	AttachmentURLs []string `json:"attachment_urls,omitempty"`
+	SnoozedUntil   int64    `json:"snoozed_until,omitempty"`
 }
 
 // ReplyType determines the type of Reply
@@ -22,6 +23,10 @@ const (
 	CONVERSATION_ASSIGN
 	CONVERSATION_OPEN
 	CONVERSATION_CLOSE
+	CONVERSATION_SNOOZE
+	CONVERSATION_UNSNOOZE // the same as CONVERSATION_OPEN, as Intercom unsnoozes by opening
+	CONVERSATION_PRIORITY
+	CONVERSATION_NOT_PRIORITY
 )
 
 var replyTypes = [...]string{
@@ -30,6 +35,10 @@ var replyTypes = [...]string{
 	"assignment",
 	"open",
 	"close",
+	"snoozed",
+	"open",
+	"priority",
+	"not_priority",
 }
 
 func (reply ReplyType) String() string {
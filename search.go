@@ -0,0 +1,193 @@
+package intercom
+
+import "time"
+
+// SearchQuery is a filter on a field, or a nested AND or OR of SearchQuerys, for the search endpoints.
+//
+//  query := intercom.And(
+//    intercom.Field("state").Eq("open"),
+//    intercom.Field("created_at").GreaterThan(time.Now().AddDate(0, 0, -7)),
+//  ).Or(intercom.Field("tag_ids").In("123", "456"))
+type SearchQuery struct {
+	Field    string      `json:"field,omitempty"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// SearchField is a field to filter on, such as "created_at" or "statistics.last_admin_reply_at".
+type SearchField string
+
+// Field starts a filter on the named field.
+func Field(name string) SearchField {
+	return SearchField(name)
+}
+
+func (f SearchField) filter(operator string, value interface{}) SearchQuery {
+	return SearchQuery{Field: string(f), Operator: operator, Value: searchValue(value)}
+}
+
+// Eq matches when the field equals the value.
+func (f SearchField) Eq(value interface{}) SearchQuery { return f.filter("=", value) }
+
+// NotEq matches when the field does not equal the value.
+func (f SearchField) NotEq(value interface{}) SearchQuery { return f.filter("!=", value) }
+
+// In matches when the field equals one of the values.
+func (f SearchField) In(values ...interface{}) SearchQuery { return f.filter("IN", searchValues(values)) }
+
+// NotIn matches when the field equals none of the values.
+func (f SearchField) NotIn(values ...interface{}) SearchQuery {
+	return f.filter("NIN", searchValues(values))
+}
+
+// GreaterThan matches when the field is greater than the value.
+func (f SearchField) GreaterThan(value interface{}) SearchQuery { return f.filter(">", value) }
+
+// LessThan matches when the field is less than the value.
+func (f SearchField) LessThan(value interface{}) SearchQuery { return f.filter("<", value) }
+
+// Contains matches when the string field contains the value.
+func (f SearchField) Contains(value string) SearchQuery { return f.filter("~", value) }
+
+// NotContains matches when the string field does not contain the value.
+func (f SearchField) NotContains(value string) SearchQuery { return f.filter("!~", value) }
+
+// StartsWith matches when the string field starts with the value.
+func (f SearchField) StartsWith(value string) SearchQuery { return f.filter("^", value) }
+
+// EndsWith matches when the string field ends with the value.
+func (f SearchField) EndsWith(value string) SearchQuery { return f.filter("$", value) }
+
+// And matches when all of the queries match.
+func And(queries ...SearchQuery) SearchQuery {
+	return SearchQuery{Operator: "AND", Value: queries}
+}
+
+// Or matches when any of the queries match.
+func Or(queries ...SearchQuery) SearchQuery {
+	return SearchQuery{Operator: "OR", Value: queries}
+}
+
+// And matches when the query and all of the others match.
+func (q SearchQuery) And(others ...SearchQuery) SearchQuery {
+	return q.combine("AND", others)
+}
+
+// Or matches when the query or any of the others match.
+func (q SearchQuery) Or(others ...SearchQuery) SearchQuery {
+	return q.combine("OR", others)
+}
+
+// combine adds the others to the query if it already has the operator, rather than nesting.
+func (q SearchQuery) combine(operator string, others []SearchQuery) SearchQuery {
+	if queries, ok := q.Value.([]SearchQuery); ok && q.Field == "" && q.Operator == operator {
+		return SearchQuery{Operator: operator, Value: append(queries[:len(queries):len(queries)], others...)}
+	}
+	return SearchQuery{Operator: operator, Value: append([]SearchQuery{q}, others...)}
+}
+
+// searchValue converts times to the Unix timestamps used by the API.
+func searchValue(value interface{}) interface{} {
+	if t, ok := value.(time.Time); ok {
+		return t.Unix()
+	}
+	return value
+}
+
+func searchValues(values []interface{}) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i := range values {
+		converted[i] = searchValue(values[i])
+	}
+	return converted
+}
+
+// SortOrder is the order of search results.
+type SortOrder int
+
+const (
+	SORT_ASCENDING SortOrder = iota
+	SORT_DESCENDING
+)
+
+var sortOrders = [...]string{
+	"ascending",
+	"descending",
+}
+
+func (order SortOrder) String() string {
+	return sortOrders[order]
+}
+
+// MarshalJSON encodes the SortOrder as the API expects.
+func (order SortOrder) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + order.String() + `"`), nil
+}
+
+// SearchSort sorts search results by a field.
+type SearchSort struct {
+	Field string    `json:"field"`
+	Order SortOrder `json:"order"`
+}
+
+// SearchPagination requests a page of search results. StartingAfter is the
+// cursor returned as Pages.Next.StartingAfter with the previous page.
+type SearchPagination struct {
+	PerPage       int64  `json:"per_page,omitempty"`
+	StartingAfter string `json:"starting_after,omitempty"`
+}
+
+// SearchRequest is a search of Conversations or Contacts.
+type SearchRequest struct {
+	Query      SearchQuery       `json:"query"`
+	Pagination *SearchPagination `json:"pagination,omitempty"`
+	Sort       *SearchSort       `json:"sort,omitempty"`
+}
+
+// NewSearch creates a SearchRequest for the query.
+func NewSearch(query SearchQuery) *SearchRequest {
+	return &SearchRequest{Query: query}
+}
+
+// SortBy sorts the results by the field.
+func (s *SearchRequest) SortBy(field string, order SortOrder) *SearchRequest {
+	s.Sort = &SearchSort{Field: field, Order: order}
+	return s
+}
+
+// PerPage sets the number of results in each page.
+func (s *SearchRequest) PerPage(perPage int64) *SearchRequest {
+	s.pagination().PerPage = perPage
+	return s
+}
+
+// StartingAfter requests the page of results after the cursor.
+func (s *SearchRequest) StartingAfter(cursor string) *SearchRequest {
+	s.pagination().StartingAfter = cursor
+	return s
+}
+
+// copy copies the SearchRequest, so that paging through results does not change the original.
+func (s *SearchRequest) copy() *SearchRequest {
+	c := *s
+	if s.Pagination != nil {
+		pagination := *s.Pagination
+		c.Pagination = &pagination
+	}
+	return &c
+}
+
+func (s *SearchRequest) pagination() *SearchPagination {
+	if s.Pagination == nil {
+		s.Pagination = &SearchPagination{}
+	}
+	return s.Pagination
+}
+
+// nextCursor returns the cursor for the page after pages, or "" if it is the last page.
+func nextCursor(pages PageParams) string {
+	if pages.Next == nil {
+		return ""
+	}
+	return pages.Next.StartingAfter
+}
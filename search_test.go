@@ -0,0 +1,61 @@
+package intercom
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSearchQueryJSON(t *testing.T) {
+	query := And(
+		Field("state").Eq("open"),
+		Field("created_at").GreaterThan(time.Unix(1560000000, 0)),
+	).And(Field("admin_assignee_id").In(1, 2)).Or(Field("statistics.last_admin_reply_at").LessThan(1500000000))
+	search := NewSearch(query).SortBy("updated_at", SORT_ASCENDING).PerPage(20).StartingAfter("abc")
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(search); err != nil {
+		t.Fatalf("Error marshalling search: %v", err)
+	}
+	data := bytes.TrimSpace(buf.Bytes())
+	expected := `{"query":{"operator":"OR","value":[` +
+		`{"operator":"AND","value":[{"field":"state","operator":"=","value":"open"},{"field":"created_at","operator":">","value":1560000000},{"field":"admin_assignee_id","operator":"IN","value":[1,2]}]},` +
+		`{"field":"statistics.last_admin_reply_at","operator":"<","value":1500000000}]},` +
+		`"pagination":{"per_page":20,"starting_after":"abc"},"sort":{"field":"updated_at","order":"ascending"}}`
+	if string(data) != expected {
+		t.Errorf("Search was %s\nexpected %s", data, expected)
+	}
+}
+
+func TestSearchQueryCombineDoesNotShareValues(t *testing.T) {
+	base := And(Field("a").Eq(1), Field("b").Eq(2))
+	one := base.And(Field("c").Eq(3))
+	two := base.And(Field("d").Eq(4))
+	if one.Value.([]SearchQuery)[2].Field != "c" || two.Value.([]SearchQuery)[2].Field != "d" {
+		t.Errorf("Combined queries were %v and %v", one, two)
+	}
+	if len(base.Value.([]SearchQuery)) != 2 {
+		t.Errorf("Base query was changed to %v", base)
+	}
+}
+
+func TestSearchFieldOperators(t *testing.T) {
+	tests := []struct {
+		query    SearchQuery
+		operator string
+	}{
+		{Field("f").NotEq(1), "!="},
+		{Field("f").NotIn(1), "NIN"},
+		{Field("f").Contains("a"), "~"},
+		{Field("f").NotContains("a"), "!~"},
+		{Field("f").StartsWith("a"), "^"},
+		{Field("f").EndsWith("a"), "$"},
+	}
+	for _, test := range tests {
+		if test.query.Operator != test.operator {
+			t.Errorf("Operator was %s, expected %s", test.query.Operator, test.operator)
+		}
+	}
+}
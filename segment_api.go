@@ -20,6 +20,7 @@ type SegmentAPI struct {
 }
 
 func (api SegmentAPI) list(ctx context.Context) (SegmentList, error) {
+	ctx = interfaces.WithOperation(ctx, "segments", "list")
 	segmentList := SegmentList{}
 	data, err := api.httpClient.Get(ctx, "/segments", nil)
 	if err != nil {
@@ -30,6 +31,7 @@ func (api SegmentAPI) list(ctx context.Context) (SegmentList, error) {
 }
 
 func (api SegmentAPI) find(ctx context.Context, id string) (Segment, error) {
+	ctx = interfaces.WithOperation(ctx, "segments", "find")
 	segment := Segment{}
 	data, err := api.httpClient.Get(ctx, fmt.Sprintf("/segments/%s", id), nil)
 	if err != nil {
@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -8,7 +9,7 @@ import (
 func TestAPIListSegments(t *testing.T) {
 	http := TestSegmentHTTPClient{t: t, fixtureFilename: "fixtures/segments.json", expectedURI: "/segments"}
 	api := SegmentAPI{httpClient: &http}
-	segmentList, err := api.list()
+	segmentList, err := api.list(context.Background())
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -23,7 +24,7 @@ func TestAPIListSegments(t *testing.T) {
 func TestAPIFindSegment(t *testing.T) {
 	http := TestSegmentHTTPClient{t: t, fixtureFilename: "fixtures/segment.json", expectedURI: "/segments/5443ac9b316c12246c000005"}
 	api := SegmentAPI{httpClient: &http}
-	segment, err := api.find("5443ac9b316c12246c000005")
+	segment, err := api.find(context.Background(), "5443ac9b316c12246c000005")
 	if err != nil {
 		t.Fatalf(err.Error())
 	}
@@ -42,7 +43,7 @@ type TestSegmentHTTPClient struct {
 	expectedURI     string
 }
 
-func (t TestSegmentHTTPClient) Get(uri string, params interface{}) ([]byte, error) {
+func (t TestSegmentHTTPClient) Get(ctx context.Context, uri string, params interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
@@ -1,9 +1,12 @@
 package intercom
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestListSegments(t *testing.T) {
-	segmentList, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).List()
+	segmentList, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).List(context.Background())
 	segments := segmentList.Segments
 	if segments[0].ID != "de412cad4" {
 		t.Errorf("Got segment with ID %s, expected de412cad4", segments[0].ID)
@@ -11,7 +14,7 @@ func TestListSegments(t *testing.T) {
 }
 
 func TestFindSegment(t *testing.T) {
-	segment, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).Find("de412cad4")
+	segment, _ := (&SegmentService{Repository: TestSegmentAPI{t: t}}).Find(context.Background(), "de412cad4")
 	if segment.ID != "de412cad4" {
 		t.Errorf("Got segment with ID %s, expected de412cad4", segment.ID)
 	}
@@ -21,10 +24,10 @@ type TestSegmentAPI struct {
 	t *testing.T
 }
 
-func (t TestSegmentAPI) list() (SegmentList, error) {
+func (t TestSegmentAPI) list(ctx context.Context) (SegmentList, error) {
 	return SegmentList{Segments: []Segment{Segment{ID: "de412cad4", Name: "My Tag"}}}, nil
 }
 
-func (t TestSegmentAPI) find(id string) (Segment, error) {
+func (t TestSegmentAPI) find(ctx context.Context, id string) (Segment, error) {
 	return Segment{ID: id}, nil
 }
@@ -22,6 +22,7 @@ type TagAPI struct {
 }
 
 func (api TagAPI) list(ctx context.Context) (TagList, error) {
+	ctx = interfaces.WithOperation(ctx, "tags", "list")
 	tagList := TagList{}
 	data, err := api.httpClient.Get(ctx, "/tags", nil)
 	if err != nil {
@@ -32,6 +33,7 @@ func (api TagAPI) list(ctx context.Context) (TagList, error) {
 }
 
 func (api TagAPI) save(ctx context.Context, tag *Tag) (Tag, error) {
+	ctx = interfaces.WithOperation(ctx, "tags", "save")
 	savedTag := Tag{}
 	data, err := api.httpClient.Post(ctx, "/tags", tag)
 	if err != nil {
@@ -42,11 +44,13 @@ func (api TagAPI) save(ctx context.Context, tag *Tag) (Tag, error) {
 }
 
 func (api TagAPI) delete(ctx context.Context, id string) error {
+	ctx = interfaces.WithOperation(ctx, "tags", "delete")
 	_, err := api.httpClient.Delete(ctx, fmt.Sprintf("/tags/%s", id), nil)
 	return err
 }
 
 func (api TagAPI) tag(ctx context.Context, taggingList *TaggingList) (Tag, error) {
+	ctx = interfaces.WithOperation(ctx, "tags", "tag")
 	savedTag := Tag{}
 	data, err := api.httpClient.Post(ctx, "/tags", taggingList)
 	if err != nil {
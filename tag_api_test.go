@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -15,7 +16,7 @@ type TestTagHTTPClient struct {
 func TestAPIListTag(t *testing.T) {
 	http := TestTagHTTPClient{t: t, fixtureFilename: "fixtures/tags.json", expectedURI: "/tags"}
 	api := TagAPI{httpClient: &http}
-	tagList, _ := api.list()
+	tagList, _ := api.list(context.Background())
 	if tagList.Tags[0].ID != "51313" {
 		t.Errorf("Tag list should start with tag 51313, but had %s", tagList.Tags[0].ID)
 	}
@@ -25,7 +26,7 @@ func TestAPITagSave(t *testing.T) {
 	http := TestTagHTTPClient{t: t, fixtureFilename: "fixtures/tag.json", expectedURI: "/tags"}
 	api := TagAPI{httpClient: &http}
 	tag := Tag{ID: "60218", Name: "My Tag"}
-	savedTag, _ := api.save(&tag)
+	savedTag, _ := api.save(context.Background(), &tag)
 	if savedTag.ID != "60218" {
 		t.Errorf("Expected saved tag with ID 60218, got %s", savedTag.ID)
 	}
@@ -34,34 +35,34 @@ func TestAPITagSave(t *testing.T) {
 func TestAPITagDelete(t *testing.T) {
 	http := TestTagHTTPClient{t: t, expectedURI: "/tags/6"}
 	api := TagAPI{httpClient: &http}
-	api.delete("6")
+	api.delete(context.Background(), "6")
 }
 
 func TestAPITagTagging(t *testing.T) {
 	http := TestTagHTTPClient{t: t, fixtureFilename: "fixtures/tag.json", expectedURI: "/tags"}
 	api := TagAPI{httpClient: &http}
 	taggingList := TaggingList{Name: "My Tag", Users: []Tagging{Tagging{UserID: "2345"}}}
-	savedTag, _ := api.tag(&taggingList)
+	savedTag, _ := api.tag(context.Background(), &taggingList)
 	if savedTag.ID != "60218" {
 		t.Errorf("Expected saved tag with ID 60218, got %s", savedTag.ID)
 	}
 }
 
-func (t TestTagHTTPClient) Get(uri string, params interface{}) ([]byte, error) {
+func (t TestTagHTTPClient) Get(ctx context.Context, uri string, params interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestTagHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+func (t TestTagHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t TestTagHTTPClient) Delete(uri string, body interface{}) ([]byte, error) {
+func (t TestTagHTTPClient) Delete(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if uri != t.expectedURI {
 		t.t.Errorf("Wrong endpoint called")
 	}
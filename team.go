@@ -0,0 +1,69 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Team represents a Team of Admins in Intercom.
+type Team struct {
+	ID       json.Number   `json:"id"`
+	Type     string        `json:"type"`
+	Name     string        `json:"name"`
+	AdminIDs []json.Number `json:"admin_ids"`
+}
+
+// TeamList represents an object holding list of Teams
+type TeamList struct {
+	Teams []Team
+}
+
+// TeamService handles interactions with the API through a TeamRepository.
+// Requires API version 2.0 or newer.
+type TeamService struct {
+	Repository TeamRepository
+}
+
+// List lists the Teams associated with your App.
+func (t *TeamService) List(ctx context.Context) (TeamList, error) {
+	return t.Repository.list(ctx)
+}
+
+// Find a Team by its ID.
+func (t *TeamService) Find(ctx context.Context, id string) (Team, error) {
+	return t.Repository.find(ctx, id)
+}
+
+// UnmarshalJSON decodes a Team whose ID and Admin IDs may be JSON numbers or strings.
+func (t *Team) UnmarshalJSON(b []byte) error {
+	type team Team
+	aux := struct {
+		*team
+		ID       json.RawMessage   `json:"id"`
+		AdminIDs []json.RawMessage `json:"admin_ids"`
+	}{team: (*team)(t)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	t.ID = json.Number(unmarshalID(aux.ID))
+	t.AdminIDs = make([]json.Number, 0, len(aux.AdminIDs))
+	for _, id := range aux.AdminIDs {
+		t.AdminIDs = append(t.AdminIDs, json.Number(unmarshalID(id)))
+	}
+	return nil
+}
+
+// HasAdmin reports whether the Admin is a member of the Team.
+func (t Team) HasAdmin(admin *Admin) bool {
+	for _, id := range t.AdminIDs {
+		if id == admin.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Team) String() string {
+	return fmt.Sprintf("[intercom] team { id: %s name: %s, admins: %d }", t.ID, t.Name, len(t.AdminIDs))
+}
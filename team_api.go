@@ -0,0 +1,49 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// TeamRepository defines the interface for working with Teams through the API.
+type TeamRepository interface {
+	list(context.Context) (TeamList, error)
+	find(context.Context, string) (Team, error)
+}
+
+// TeamAPI implements TeamRepository
+type TeamAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+func (api TeamAPI) list(ctx context.Context) (TeamList, error) {
+	ctx = interfaces.WithOperation(ctx, "teams", "list")
+	teamList := TeamList{}
+	if err := requireVersion(api.httpClient, "2.0", "GET", "/teams"); err != nil {
+		return teamList, err
+	}
+	data, err := api.httpClient.Get(ctx, "/teams", nil)
+	if err != nil {
+		return teamList, err
+	}
+	err = json.Unmarshal(data, &teamList)
+	return teamList, err
+}
+
+func (api TeamAPI) find(ctx context.Context, id string) (Team, error) {
+	ctx = interfaces.WithOperation(ctx, "teams", "find")
+	team := Team{}
+	path := fmt.Sprintf("/teams/%s", id)
+	if err := requireVersion(api.httpClient, "2.0", "GET", path); err != nil {
+		return team, err
+	}
+	data, err := api.httpClient.Get(ctx, path, nil)
+	if err != nil {
+		return team, err
+	}
+	err = json.Unmarshal(data, &team)
+	return team, err
+}
@@ -0,0 +1,63 @@
+package intercom
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestTeamAPIList(t *testing.T) {
+	http := TestTeamHTTPClient{fixtureFilename: "fixtures/teams.json", expectedURI: "/teams", t: t}
+	api := TeamAPI{httpClient: &http}
+	teamList, _ := api.list(context.Background())
+	if teamList.Teams[0].ID != "814865" {
+		t.Errorf("ID was %s, expected 814865", teamList.Teams[0].ID)
+	}
+	if len(teamList.Teams[0].AdminIDs) != 2 || teamList.Teams[0].AdminIDs[1] != "2" {
+		t.Errorf("AdminIDs were %v, expected [1 2]", teamList.Teams[0].AdminIDs)
+	}
+}
+
+func TestTeamAPIFind(t *testing.T) {
+	http := TestTeamHTTPClient{fixtureFilename: "fixtures/team.json", expectedURI: "/teams/814865", t: t}
+	api := TeamAPI{httpClient: &http}
+	team, err := api.find(context.Background(), "814865")
+	if err != nil {
+		t.Fatalf("Error finding team: %v", err)
+	}
+	if team.ID != "814865" || team.Name != "Support" {
+		t.Errorf("Team was %s, expected Support", team)
+	}
+	if len(team.AdminIDs) != 2 || team.AdminIDs[0] != "1" {
+		t.Errorf("AdminIDs were %v, expected [1 2]", team.AdminIDs)
+	}
+}
+
+func TestTeamAPIRequiresVersion(t *testing.T) {
+	ic := NewClient("a", "b")
+	ic.Option(APIVersion("1.4"))
+	_, err := ic.Teams.List(context.Background())
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("List error was %v, expected a VersionError", err)
+	}
+	_, err = ic.Teams.Find(context.Background(), "814865")
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Find error was %v, expected a VersionError", err)
+	}
+}
+
+type TestTeamHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	fixtureFilename string
+	expectedURI     string
+}
+
+func (t TestTeamHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	if t.expectedURI != uri {
+		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
+	}
+	return ioutil.ReadFile(t.fixtureFilename)
+}
@@ -0,0 +1,45 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTeamList(t *testing.T) {
+	teamService := TeamService{Repository: TestTeamAPI{t: t}}
+	teamList, _ := teamService.List(context.Background())
+	if teamList.Teams[0].ID != "814865" {
+		t.Errorf("Team not found")
+	}
+}
+
+func TestTeamFind(t *testing.T) {
+	teamService := TeamService{Repository: TestTeamAPI{t: t}}
+	team, _ := teamService.Find(context.Background(), "814865")
+	if team.ID != "814865" {
+		t.Errorf("Team not found")
+	}
+}
+
+func TestTeamHasAdmin(t *testing.T) {
+	team := Team{ID: "814865", AdminIDs: []json.Number{"1", "2"}}
+	if !team.HasAdmin(&Admin{ID: "2"}) {
+		t.Errorf("Admin 2 was not in the Team")
+	}
+	if team.HasAdmin(&Admin{ID: "3"}) {
+		t.Errorf("Admin 3 was in the Team")
+	}
+}
+
+type TestTeamAPI struct {
+	t *testing.T
+}
+
+func (t TestTeamAPI) list(ctx context.Context) (TeamList, error) {
+	return TeamList{Teams: []Team{Team{ID: "814865"}}}, nil
+}
+
+func (t TestTeamAPI) find(ctx context.Context, id string) (Team, error) {
+	return Team{ID: json.Number(id)}, nil
+}
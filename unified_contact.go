@@ -0,0 +1,178 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// UnifiedContactService handles interactions with the API through a UnifiedContactRepository.
+// It uses the unified Contacts API of API version 2.0 or newer, in which Users and Leads
+// are both Contacts, distinguished by their Role.
+type UnifiedContactService struct {
+	Repository UnifiedContactRepository
+}
+
+// ContactRole is the role of a UnifiedContact.
+type ContactRole int
+
+const (
+	ROLE_USER ContactRole = iota
+	ROLE_LEAD
+)
+
+var contactRoles = [...]string{
+	"user",
+	"lead",
+}
+
+func (role ContactRole) String() string {
+	return contactRoles[role]
+}
+
+// UnifiedContactList holds a list of UnifiedContacts and paging information.
+type UnifiedContactList struct {
+	Pages    PageParams       `json:"pages"`
+	Contacts []UnifiedContact `json:"data"`
+	// TotalCount is the number of Contacts in the list, or matching a search.
+	TotalCount int64 `json:"total_count,omitempty"`
+}
+
+// UnifiedContact represents a User or Lead in the unified Contacts API.
+// Not all of the fields are writeable to the API, non-writeable fields are
+// stripped out from the request. Please see the API documentation for details.
+type UnifiedContact struct {
+	ID                     string                 `json:"id,omitempty"`
+	Role                   string                 `json:"role,omitempty"`
+	ExternalID             string                 `json:"external_id,omitempty"`
+	Email                  string                 `json:"email,omitempty"`
+	Phone                  string                 `json:"phone,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	OwnerID                int64                  `json:"owner_id,omitempty"`
+	SignedUpAt             int64                  `json:"signed_up_at,omitempty"`
+	LastSeenAt             int64                  `json:"last_seen_at,omitempty"`
+	CreatedAt              int64                  `json:"created_at,omitempty"`
+	UpdatedAt              int64                  `json:"updated_at,omitempty"`
+	UnsubscribedFromEmails *bool                  `json:"unsubscribed_from_emails,omitempty"`
+	Tags                   *AddressableList       `json:"tags,omitempty"`
+	Companies              *AddressableList       `json:"companies,omitempty"`
+	CustomAttributes       map[string]interface{} `json:"custom_attributes,omitempty"`
+	Archived               bool                   `json:"archived,omitempty"`
+	Deleted                bool                   `json:"deleted,omitempty"`
+}
+
+// AddressableList references objects attached to a UnifiedContact, such as its Tags.
+type AddressableList struct {
+	Type       string        `json:"type,omitempty"`
+	Data       []Addressable `json:"data"`
+	URL        string        `json:"url,omitempty"`
+	TotalCount int64         `json:"total_count,omitempty"`
+	HasMore    bool          `json:"has_more,omitempty"`
+}
+
+// Addressable references an object by its type and ID.
+type Addressable struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Find a Contact by their Intercom ID.
+func (c *UnifiedContactService) Find(ctx context.Context, id string) (UnifiedContact, error) {
+	return c.Repository.find(ctx, id)
+}
+
+// Create a Contact with the role they are given. Users must have an ExternalID or Email.
+func (c *UnifiedContactService) Create(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	switch contact.Role {
+	case ROLE_USER.String():
+		if contact.ExternalID == "" && contact.Email == "" {
+			return UnifiedContact{}, errors.New("Missing User ExternalID or Email")
+		}
+	case ROLE_LEAD.String():
+	case "":
+		return UnifiedContact{}, errors.New("Missing Contact Role")
+	default:
+		return UnifiedContact{}, fmt.Errorf("Unknown Contact Role %s", contact.Role)
+	}
+	return c.Repository.create(ctx, contact)
+}
+
+// CreateUser creates a Contact with the user role.
+func (c *UnifiedContactService) CreateUser(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	contact.Role = ROLE_USER.String()
+	return c.Create(ctx, contact)
+}
+
+// CreateLead creates a Contact with the lead role.
+func (c *UnifiedContactService) CreateLead(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	contact.Role = ROLE_LEAD.String()
+	return c.Create(ctx, contact)
+}
+
+// Update a Contact, identified by their ID. Setting the Role of a Lead to user converts them.
+func (c *UnifiedContactService) Update(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	if contact.ID == "" {
+		return UnifiedContact{}, errors.New("Missing Contact ID")
+	}
+	return c.Repository.update(ctx, contact)
+}
+
+// Archive a Contact, hiding them from lists and searches until they are unarchived.
+func (c *UnifiedContactService) Archive(ctx context.Context, id string) (UnifiedContact, error) {
+	return c.Repository.archive(ctx, id)
+}
+
+// Unarchive an archived Contact.
+func (c *UnifiedContactService) Unarchive(ctx context.Context, id string) (UnifiedContact, error) {
+	return c.Repository.unarchive(ctx, id)
+}
+
+// Merge a Lead into a User, returning the User.
+func (c *UnifiedContactService) Merge(ctx context.Context, leadID, userID string) (UnifiedContact, error) {
+	return c.Repository.merge(ctx, leadID, userID)
+}
+
+// Delete a Contact.
+func (c *UnifiedContactService) Delete(ctx context.Context, id string) (UnifiedContact, error) {
+	return c.Repository.delete(ctx, id)
+}
+
+// Search Contacts, as ContactService.Search, returning UnifiedContacts.
+func (c *UnifiedContactService) Search(ctx context.Context, search *SearchRequest) (UnifiedContactList, error) {
+	return c.Repository.search(ctx, search)
+}
+
+// IterateSearch iterates over all Contacts matching a search, from search.Pagination.StartingAfter onwards.
+func (c *UnifiedContactService) IterateSearch(ctx context.Context, search *SearchRequest) *Iterator[UnifiedContact] {
+	search = search.copy()
+	return newCursorIterator(ctx, func(ctx context.Context, cursor string) ([]UnifiedContact, string, error) {
+		if cursor != "" {
+			search.StartingAfter(cursor)
+		}
+		contactList, err := c.Search(ctx, search)
+		return contactList.Contacts, nextCursor(contactList.Pages), err
+	})
+}
+
+// IsUser reports whether the Contact has the user role.
+func (c UnifiedContact) IsUser() bool {
+	return c.Role == ROLE_USER.String()
+}
+
+// IsLead reports whether the Contact has the lead role.
+func (c UnifiedContact) IsLead() bool {
+	return c.Role == ROLE_LEAD.String()
+}
+
+// MessageAddress gets the address for a Contact in order to message them
+func (c UnifiedContact) MessageAddress() MessageAddress {
+	if c.IsLead() {
+		return MessageAddress{Type: "contact", ID: c.ID, Email: c.Email}
+	}
+	return MessageAddress{Type: "user", ID: c.ID, Email: c.Email, UserID: c.ExternalID}
+}
+
+func (c UnifiedContact) String() string {
+	return fmt.Sprintf("[intercom] %s contact { id: %s name: %s, external_id: %s, email: %s }", c.Role, c.ID, c.Name, c.ExternalID, c.Email)
+}
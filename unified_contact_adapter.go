@@ -0,0 +1,332 @@
+package intercom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// UnifiedContact converts a User to a Contact with the user role, with their UserID as ExternalID.
+func (u User) UnifiedContact() UnifiedContact {
+	return UnifiedContact{
+		ID:                     u.ID,
+		Role:                   ROLE_USER.String(),
+		ExternalID:             u.UserID,
+		Email:                  u.Email,
+		Phone:                  u.Phone,
+		Name:                   u.Name,
+		SignedUpAt:             u.SignedUpAt,
+		LastSeenAt:             u.LastRequestAt,
+		CreatedAt:              u.CreatedAt,
+		UpdatedAt:              u.UpdatedAt,
+		UnsubscribedFromEmails: u.UnsubscribedFromEmails,
+		CustomAttributes:       u.CustomAttributes,
+	}
+}
+
+// UnifiedContact converts a Contact to a Contact with the lead role, with their UserID as ExternalID.
+func (c Contact) UnifiedContact() UnifiedContact {
+	return UnifiedContact{
+		ID:                     c.ID,
+		Role:                   ROLE_LEAD.String(),
+		ExternalID:             c.UserID,
+		Email:                  c.Email,
+		Phone:                  c.Phone,
+		Name:                   c.Name,
+		LastSeenAt:             c.LastRequestAt,
+		CreatedAt:              c.CreatedAt,
+		UpdatedAt:              c.UpdatedAt,
+		UnsubscribedFromEmails: c.UnsubscribedFromEmails,
+		CustomAttributes:       c.CustomAttributes,
+	}
+}
+
+// User converts a UnifiedContact to a User, with their ExternalID as UserID.
+func (c UnifiedContact) User() User {
+	return User{
+		ID:                     c.ID,
+		UserID:                 c.ExternalID,
+		Email:                  c.Email,
+		Phone:                  c.Phone,
+		Name:                   c.Name,
+		SignedUpAt:             c.SignedUpAt,
+		LastRequestAt:          c.LastSeenAt,
+		CreatedAt:              c.CreatedAt,
+		UpdatedAt:              c.UpdatedAt,
+		UnsubscribedFromEmails: c.UnsubscribedFromEmails,
+		CustomAttributes:       c.CustomAttributes,
+	}
+}
+
+// Contact converts a UnifiedContact to a Contact, with their ExternalID as UserID.
+func (c UnifiedContact) Contact() Contact {
+	return Contact{
+		ID:                     c.ID,
+		UserID:                 c.ExternalID,
+		Email:                  c.Email,
+		Phone:                  c.Phone,
+		Name:                   c.Name,
+		LastRequestAt:          c.LastSeenAt,
+		CreatedAt:              c.CreatedAt,
+		UpdatedAt:              c.UpdatedAt,
+		UnsubscribedFromEmails: c.UnsubscribedFromEmails,
+		CustomAttributes:       c.CustomAttributes,
+	}
+}
+
+// unifiedAdapter finds and lists Contacts of a role through a UnifiedContactRepository.
+type unifiedAdapter struct {
+	contacts UnifiedContactRepository
+	role     ContactRole
+}
+
+func (a unifiedAdapter) notFound(path string) error {
+	return interfaces.HTTPError{StatusCode: http.StatusNotFound, Code: "not_found", Message: "Contact Not Found", Path: path}
+}
+
+func isNotFound(err error) bool {
+	var herr interfaces.HTTPError
+	return errors.As(err, &herr) && herr.StatusCode == http.StatusNotFound
+}
+
+// find looks up a Contact of the role by ID, or else by ExternalID or Email.
+func (a unifiedAdapter) find(ctx context.Context, params UserIdentifiers) (UnifiedContact, error) {
+	switch {
+	case params.ID != "":
+		contact, err := a.contacts.find(ctx, params.ID)
+		if err == nil && contact.Role != a.role.String() {
+			return UnifiedContact{}, a.notFound("/contacts/" + params.ID)
+		}
+		return contact, err
+	case params.UserID != "":
+		return a.findBy(ctx, Field("external_id").Eq(params.UserID))
+	case params.Email != "":
+		return a.findBy(ctx, Field("email").Eq(params.Email))
+	}
+	return UnifiedContact{}, errors.New("Missing Contact Identifier")
+}
+
+// delete removes a Contact of the role by ID, finding them first so a Contact of another role is not found.
+func (a unifiedAdapter) delete(ctx context.Context, id string) (UnifiedContact, error) {
+	if _, err := a.find(ctx, UserIdentifiers{ID: id}); err != nil {
+		return UnifiedContact{}, err
+	}
+	return a.contacts.delete(ctx, id)
+}
+
+func (a unifiedAdapter) findBy(ctx context.Context, query SearchQuery) (UnifiedContact, error) {
+	contactList, err := a.contacts.search(ctx, NewSearch(a.query(query)).PerPage(1))
+	if err != nil {
+		return UnifiedContact{}, err
+	}
+	if len(contactList.Contacts) == 0 {
+		return UnifiedContact{}, a.notFound("/contacts/search")
+	}
+	return contactList.Contacts[0], nil
+}
+
+// query restricts the query to Contacts of the role.
+func (a unifiedAdapter) query(queries ...SearchQuery) SearchQuery {
+	return And(append([]SearchQuery{Field("role").Eq(a.role.String())}, queries...)...)
+}
+
+// list fetches a numbered page of Contacts. Given the Next cursor of the previous page it is a single
+// search, otherwise the search cursor is walked from the first page, taking one search per page.
+func (a unifiedAdapter) list(ctx context.Context, params PageParams, queries ...SearchQuery) ([]UnifiedContact, PageParams, error) {
+	if params.PerPage == 0 {
+		params.PerPage = 50
+	}
+	search := NewSearch(a.query(queries...)).PerPage(params.PerPage)
+	page := int64(1)
+	if params.Next != nil && params.Next.StartingAfter != "" {
+		search.StartingAfter(params.Next.StartingAfter)
+		if params.Next.Page != 0 {
+			params.Page = params.Next.Page
+		}
+		page = params.Page
+	}
+	if params.Page == 0 {
+		params.Page = 1
+		page = 1
+	}
+	pages := PageParams{Page: params.Page, PerPage: params.PerPage}
+	for ; ; page++ {
+		contactList, err := a.contacts.search(ctx, search)
+		if err != nil {
+			return nil, pages, err
+		}
+		pages.TotalPages = (contactList.TotalCount + params.PerPage - 1) / params.PerPage
+		cursor := nextCursor(contactList.Pages)
+		if page == params.Page {
+			if cursor != "" {
+				pages.Next = &PageCursor{Page: page + 1, StartingAfter: cursor}
+			}
+			return contactList.Contacts, pages, nil
+		}
+		if cursor == "" {
+			return []UnifiedContact{}, pages, nil
+		}
+		search.StartingAfter(cursor)
+	}
+}
+
+// scroll fetches the page of Contacts after the cursor, returning the cursor for the next page.
+func (a unifiedAdapter) scroll(ctx context.Context, cursor string) ([]UnifiedContact, string, error) {
+	search := NewSearch(a.query()).PerPage(50)
+	if cursor != "" {
+		search.StartingAfter(cursor)
+	}
+	contactList, err := a.contacts.search(ctx, search)
+	return contactList.Contacts, nextCursor(contactList.Pages), err
+}
+
+// UnifiedUserAPI implements UserRepository with the unified Contacts API, for Contacts with the user role.
+// Tags, Segments and Companies of Users are not saved, and Scroll cursors are search cursors.
+type UnifiedUserAPI struct {
+	adapter unifiedAdapter
+}
+
+// NewUnifiedUserAPI adapts a UnifiedContactRepository to a UserRepository.
+func NewUnifiedUserAPI(contacts UnifiedContactRepository) UnifiedUserAPI {
+	return UnifiedUserAPI{adapter: unifiedAdapter{contacts: contacts, role: ROLE_USER}}
+}
+
+func (api UnifiedUserAPI) find(ctx context.Context, params UserIdentifiers) (User, error) {
+	contact, err := api.adapter.find(ctx, params)
+	return contact.User(), err
+}
+
+func (api UnifiedUserAPI) list(ctx context.Context, params userListParams) (UserList, error) {
+	contacts, pages, err := api.adapter.list(ctx, params.PageParams, filters(params.SegmentID, params.TagID, "")...)
+	return UserList{Users: toUsers(contacts), Pages: pages}, err
+}
+
+func (api UnifiedUserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
+	contacts, cursor, err := api.adapter.scroll(ctx, scrollParam)
+	return UserList{Users: toUsers(contacts), ScrollParam: cursor}, err
+}
+
+// save updates the User with the same ID, UserID or Email, or creates them if there is none.
+func (api UnifiedUserAPI) save(ctx context.Context, user *User) (User, error) {
+	contact := user.UnifiedContact()
+	existing, err := api.adapter.find(ctx, UserIdentifiers{ID: user.ID, UserID: user.UserID, Email: user.Email})
+	switch {
+	case err == nil:
+		contact.ID = existing.ID
+		contact, err = api.adapter.contacts.update(ctx, &contact)
+	case isNotFound(err) && user.ID == "":
+		contact, err = api.adapter.contacts.create(ctx, &contact)
+	default:
+		return User{}, err
+	}
+	return contact.User(), err
+}
+
+func (api UnifiedUserAPI) delete(ctx context.Context, id string) (User, error) {
+	contact, err := api.adapter.delete(ctx, id)
+	return contact.User(), err
+}
+
+// UnifiedLeadAPI implements ContactRepository with the unified Contacts API, for Contacts with the lead role.
+// Converting a Contact merges them into the User, creating the User if they do not exist.
+type UnifiedLeadAPI struct {
+	adapter unifiedAdapter
+}
+
+// NewUnifiedLeadAPI adapts a UnifiedContactRepository to a ContactRepository.
+func NewUnifiedLeadAPI(contacts UnifiedContactRepository) UnifiedLeadAPI {
+	return UnifiedLeadAPI{adapter: unifiedAdapter{contacts: contacts, role: ROLE_LEAD}}
+}
+
+func (api UnifiedLeadAPI) find(ctx context.Context, params UserIdentifiers) (Contact, error) {
+	contact, err := api.adapter.find(ctx, params)
+	return contact.Contact(), err
+}
+
+func (api UnifiedLeadAPI) list(ctx context.Context, params contactListParams) (ContactList, error) {
+	contacts, pages, err := api.adapter.list(ctx, params.PageParams, filters(params.SegmentID, params.TagID, params.Email)...)
+	return ContactList{Contacts: toContacts(contacts), Pages: pages}, err
+}
+
+func (api UnifiedLeadAPI) scroll(ctx context.Context, scrollParam string) (ContactList, error) {
+	contacts, cursor, err := api.adapter.scroll(ctx, scrollParam)
+	return ContactList{Contacts: toContacts(contacts), ScrollParam: cursor}, err
+}
+
+func (api UnifiedLeadAPI) search(ctx context.Context, search *SearchRequest) (ContactList, error) {
+	leadSearch := search.copy()
+	leadSearch.Query = api.adapter.query(search.Query)
+	contactList, err := api.adapter.contacts.search(ctx, leadSearch)
+	return ContactList{Contacts: toContacts(contactList.Contacts), Pages: contactList.Pages, TotalCount: contactList.TotalCount}, err
+}
+
+func (api UnifiedLeadAPI) create(ctx context.Context, contact *Contact) (Contact, error) {
+	lead := contact.UnifiedContact()
+	lead, err := api.adapter.contacts.create(ctx, &lead)
+	return lead.Contact(), err
+}
+
+func (api UnifiedLeadAPI) update(ctx context.Context, contact *Contact) (Contact, error) {
+	lead := contact.UnifiedContact()
+	if lead.ID == "" {
+		existing, err := api.adapter.find(ctx, UserIdentifiers{UserID: contact.UserID})
+		if err != nil {
+			return Contact{}, err
+		}
+		lead.ID = existing.ID
+	}
+	lead, err := api.adapter.contacts.update(ctx, &lead)
+	return lead.Contact(), err
+}
+
+func (api UnifiedLeadAPI) convert(ctx context.Context, contact *Contact, user *User) (User, error) {
+	users := unifiedAdapter{contacts: api.adapter.contacts, role: ROLE_USER}
+	existing, err := users.find(ctx, UserIdentifiers{ID: user.ID, UserID: user.UserID, Email: user.Email})
+	if isNotFound(err) && user.ID == "" {
+		created := user.UnifiedContact()
+		existing, err = api.adapter.contacts.create(ctx, &created)
+	}
+	if err != nil {
+		return User{}, err
+	}
+	merged, err := api.adapter.contacts.merge(ctx, contact.ID, existing.ID)
+	return merged.User(), err
+}
+
+func (api UnifiedLeadAPI) delete(ctx context.Context, id string) (Contact, error) {
+	contact, err := api.adapter.delete(ctx, id)
+	return contact.Contact(), err
+}
+
+// filters are the search queries for the list parameters of the User and Contact APIs.
+func filters(segmentID, tagID, email string) []SearchQuery {
+	queries := []SearchQuery{}
+	if segmentID != "" {
+		queries = append(queries, Field("segment_id").Eq(segmentID))
+	}
+	if tagID != "" {
+		queries = append(queries, Field("tag_id").Eq(tagID))
+	}
+	if email != "" {
+		queries = append(queries, Field("email").Eq(email))
+	}
+	return queries
+}
+
+func toUsers(contacts []UnifiedContact) []User {
+	users := make([]User, len(contacts))
+	for i := range contacts {
+		users[i] = contacts[i].User()
+	}
+	return users
+}
+
+func toContacts(contacts []UnifiedContact) []Contact {
+	leads := make([]Contact, len(contacts))
+	for i := range contacts {
+		leads[i] = contacts[i].Contact()
+	}
+	return leads
+}
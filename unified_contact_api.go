@@ -0,0 +1,143 @@
+package intercom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+// UnifiedContactRepository defines the interface for working with UnifiedContacts through the API.
+type UnifiedContactRepository interface {
+	find(context.Context, string) (UnifiedContact, error)
+	search(context.Context, *SearchRequest) (UnifiedContactList, error)
+	create(context.Context, *UnifiedContact) (UnifiedContact, error)
+	update(context.Context, *UnifiedContact) (UnifiedContact, error)
+	archive(context.Context, string) (UnifiedContact, error)
+	unarchive(context.Context, string) (UnifiedContact, error)
+	merge(context.Context, string, string) (UnifiedContact, error)
+	delete(context.Context, string) (UnifiedContact, error)
+}
+
+// UnifiedContactAPI implements UnifiedContactRepository
+type UnifiedContactAPI struct {
+	httpClient interfaces.HTTPClient
+}
+
+type requestUnifiedContact struct {
+	Role                   string                 `json:"role,omitempty"`
+	ExternalID             string                 `json:"external_id,omitempty"`
+	Email                  string                 `json:"email,omitempty"`
+	Phone                  string                 `json:"phone,omitempty"`
+	Name                   string                 `json:"name,omitempty"`
+	OwnerID                int64                  `json:"owner_id,omitempty"`
+	SignedUpAt             int64                  `json:"signed_up_at,omitempty"`
+	LastSeenAt             int64                  `json:"last_seen_at,omitempty"`
+	UnsubscribedFromEmails *bool                  `json:"unsubscribed_from_emails,omitempty"`
+	CustomAttributes       map[string]interface{} `json:"custom_attributes,omitempty"`
+}
+
+type requestMerge struct {
+	From string `json:"from"`
+	Into string `json:"into"`
+}
+
+func (api UnifiedContactAPI) find(ctx context.Context, id string) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "find")
+	path := fmt.Sprintf("/contacts/%s", id)
+	if err := requireVersion(api.httpClient, "2.0", "GET", path); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(api.httpClient.Get(ctx, path, nil))
+}
+
+func (api UnifiedContactAPI) search(ctx context.Context, search *SearchRequest) (UnifiedContactList, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "search")
+	contactList := UnifiedContactList{}
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/contacts/search"); err != nil {
+		return contactList, err
+	}
+	data, err := api.httpClient.Post(ctx, "/contacts/search", search)
+	if err != nil {
+		return contactList, err
+	}
+	err = json.Unmarshal(data, &contactList)
+	return contactList, err
+}
+
+func (api UnifiedContactAPI) create(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "create")
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/contacts"); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(api.httpClient.Post(ctx, "/contacts", api.buildRequestContact(contact)))
+}
+
+func (api UnifiedContactAPI) update(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "update")
+	path := fmt.Sprintf("/contacts/%s", contact.ID)
+	if err := requireVersion(api.httpClient, "2.0", "PUT", path); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(interfaces.Put(ctx, api.httpClient, path, api.buildRequestContact(contact)))
+}
+
+func (api UnifiedContactAPI) archive(ctx context.Context, id string) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "archive")
+	return api.postAction(ctx, id, "archive")
+}
+
+func (api UnifiedContactAPI) unarchive(ctx context.Context, id string) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "unarchive")
+	return api.postAction(ctx, id, "unarchive")
+}
+
+func (api UnifiedContactAPI) postAction(ctx context.Context, id, action string) (UnifiedContact, error) {
+	path := fmt.Sprintf("/contacts/%s/%s", id, action)
+	if err := requireVersion(api.httpClient, "2.0", "POST", path); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(api.httpClient.Post(ctx, path, nil))
+}
+
+func (api UnifiedContactAPI) merge(ctx context.Context, leadID, userID string) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "merge")
+	if err := requireVersion(api.httpClient, "2.0", "POST", "/contacts/merge"); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(api.httpClient.Post(ctx, "/contacts/merge", requestMerge{From: leadID, Into: userID}))
+}
+
+func (api UnifiedContactAPI) delete(ctx context.Context, id string) (UnifiedContact, error) {
+	ctx = interfaces.WithOperation(ctx, "contacts", "delete")
+	path := fmt.Sprintf("/contacts/%s", id)
+	if err := requireVersion(api.httpClient, "2.0", "DELETE", path); err != nil {
+		return UnifiedContact{}, err
+	}
+	return unmarshalToUnifiedContact(api.httpClient.Delete(ctx, path, nil))
+}
+
+func unmarshalToUnifiedContact(data []byte, err error) (UnifiedContact, error) {
+	savedContact := UnifiedContact{}
+	if err != nil {
+		return savedContact, err
+	}
+	err = json.Unmarshal(data, &savedContact)
+	return savedContact, err
+}
+
+func (api UnifiedContactAPI) buildRequestContact(contact *UnifiedContact) requestUnifiedContact {
+	return requestUnifiedContact{
+		Role:                   contact.Role,
+		ExternalID:             contact.ExternalID,
+		Email:                  contact.Email,
+		Phone:                  contact.Phone,
+		Name:                   contact.Name,
+		OwnerID:                contact.OwnerID,
+		SignedUpAt:             contact.SignedUpAt,
+		LastSeenAt:             contact.LastSeenAt,
+		UnsubscribedFromEmails: contact.UnsubscribedFromEmails,
+		CustomAttributes:       contact.CustomAttributes,
+	}
+}
@@ -0,0 +1,102 @@
+package intercom
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestUnifiedContactAPIFind(t *testing.T) {
+	http := TestUnifiedContactHTTPClient{t: t, fixtureFilename: "fixtures/unified_contact.json", expectedMethod: "GET", expectedURI: "/contacts/5ba682d23d7cf92bef87bfd4"}
+	api := UnifiedContactAPI{httpClient: &http}
+	contact, err := api.find(context.Background(), "5ba682d23d7cf92bef87bfd4")
+	if err != nil {
+		t.Errorf("Error parsing fixture %s", err)
+	}
+	if contact.ID != "5ba682d23d7cf92bef87bfd4" || contact.ExternalID != "25" || !contact.IsUser() {
+		t.Errorf("Contact was %v", contact)
+	}
+	if contact.Tags == nil || contact.Tags.Data[0].ID != "2" || contact.CustomAttributes["plan"] != "pro" {
+		t.Errorf("Contact tags and attributes were %v %v", contact.Tags, contact.CustomAttributes)
+	}
+}
+
+func TestUnifiedContactAPIUpdate(t *testing.T) {
+	http := TestUnifiedContactHTTPClient{t: t, fixtureFilename: "fixtures/unified_contact.json", expectedMethod: "PUT", expectedURI: "/contacts/5ba682d23d7cf92bef87bfd4"}
+	api := UnifiedContactAPI{httpClient: &http}
+	api.update(context.Background(), &UnifiedContact{ID: "5ba682d23d7cf92bef87bfd4", Role: "user", Name: "Jamie", CreatedAt: 10})
+	body, ok := http.lastBody.(requestUnifiedContact)
+	if !ok || body.Name != "Jamie" || body.Role != "user" {
+		t.Errorf("Body was %v", http.lastBody)
+	}
+}
+
+func TestUnifiedContactAPIActions(t *testing.T) {
+	tests := []struct {
+		method, uri string
+		call        func(UnifiedContactAPI) (UnifiedContact, error)
+	}{
+		{"POST", "/contacts", func(api UnifiedContactAPI) (UnifiedContact, error) {
+			return api.create(context.Background(), &UnifiedContact{Role: "lead"})
+		}},
+		{"POST", "/contacts/1/archive", func(api UnifiedContactAPI) (UnifiedContact, error) { return api.archive(context.Background(), "1") }},
+		{"POST", "/contacts/1/unarchive", func(api UnifiedContactAPI) (UnifiedContact, error) { return api.unarchive(context.Background(), "1") }},
+		{"POST", "/contacts/merge", func(api UnifiedContactAPI) (UnifiedContact, error) { return api.merge(context.Background(), "1", "2") }},
+		{"DELETE", "/contacts/1", func(api UnifiedContactAPI) (UnifiedContact, error) { return api.delete(context.Background(), "1") }},
+	}
+	for _, test := range tests {
+		http := TestUnifiedContactHTTPClient{t: t, fixtureFilename: "fixtures/unified_contact.json", expectedMethod: test.method, expectedURI: test.uri}
+		if _, err := test.call(UnifiedContactAPI{httpClient: &http}); err != nil {
+			t.Errorf("%s %s failed: %v", test.method, test.uri, err)
+		}
+	}
+	http := TestUnifiedContactHTTPClient{t: t, fixtureFilename: "fixtures/unified_contact.json", expectedMethod: "POST", expectedURI: "/contacts/merge"}
+	(UnifiedContactAPI{httpClient: &http}).merge(context.Background(), "1", "2")
+	if merge, ok := http.lastBody.(requestMerge); !ok || merge.From != "1" || merge.Into != "2" {
+		t.Errorf("Merge was %v", http.lastBody)
+	}
+}
+
+func TestUnifiedContactAPIRequiresVersion(t *testing.T) {
+	ic := NewClient("a", "b")
+	ic.Option(APIVersion("1.4"))
+	_, err := ic.UnifiedContacts.Find(context.Background(), "1")
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Error was %v, expected a VersionError", err)
+	}
+}
+
+type TestUnifiedContactHTTPClient struct {
+	TestHTTPClient
+	t               *testing.T
+	fixtureFilename string
+	expectedMethod  string
+	expectedURI     string
+	lastBody        interface{}
+}
+
+func (t *TestUnifiedContactHTTPClient) request(method, uri string, body interface{}) ([]byte, error) {
+	if t.expectedMethod != method || t.expectedURI != uri {
+		t.t.Errorf("Called %s %s, expected %s %s", method, uri, t.expectedMethod, t.expectedURI)
+	}
+	t.lastBody = body
+	return ioutil.ReadFile(t.fixtureFilename)
+}
+
+func (t *TestUnifiedContactHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	return t.request("GET", uri, queryParams)
+}
+
+func (t *TestUnifiedContactHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return t.request("POST", uri, body)
+}
+
+func (t *TestUnifiedContactHTTPClient) Put(ctx context.Context, uri string, body interface{}) ([]byte, error) {
+	return t.request("PUT", uri, body)
+}
+
+func (t *TestUnifiedContactHTTPClient) Delete(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
+	return t.request("DELETE", uri, queryParams)
+}
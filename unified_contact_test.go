@@ -0,0 +1,254 @@
+package intercom
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestUnifiedContactCreateChecksRole(t *testing.T) {
+	service := UnifiedContactService{Repository: &TestUnifiedContactAPI{}}
+	ctx := context.Background()
+	if _, err := service.Create(ctx, &UnifiedContact{Email: "jamie@example.io"}); err == nil {
+		t.Errorf("Created Contact without a Role")
+	}
+	if _, err := service.CreateUser(ctx, &UnifiedContact{Name: "Jamie"}); err == nil {
+		t.Errorf("Created User without an ExternalID or Email")
+	}
+	lead, err := service.CreateLead(ctx, &UnifiedContact{Name: "Jamie"})
+	if err != nil || !lead.IsLead() || lead.ID == "" {
+		t.Errorf("Lead was %v, error %v", lead, err)
+	}
+	if _, err := service.Update(ctx, &UnifiedContact{Name: "Jamie"}); err == nil {
+		t.Errorf("Updated Contact without an ID")
+	}
+}
+
+func TestUnifiedUserAPISave(t *testing.T) {
+	api := &TestUnifiedContactAPI{}
+	users := UserService{Repository: NewUnifiedUserAPI(api)}
+	ctx := context.Background()
+
+	created, err := users.Save(ctx, &User{UserID: "27", Email: "jamie@example.io", Name: "Jamie"})
+	if err != nil || created.ID == "" || api.contacts[0].Role != "user" || api.contacts[0].ExternalID != "27" {
+		t.Errorf("User was %v, error %v, contacts %v", created, err, api.contacts)
+	}
+	updated, err := users.Save(ctx, &User{UserID: "27", Name: "Jamie Smith"})
+	if err != nil || updated.ID != created.ID || len(api.contacts) != 1 || api.contacts[0].Name != "Jamie Smith" {
+		t.Errorf("User was %v, error %v, contacts %v", updated, err, api.contacts)
+	}
+	found, err := users.FindByEmail(ctx, "jamie@example.io")
+	if err != nil || found.UserID != "27" {
+		t.Errorf("User was %v, error %v", found, err)
+	}
+}
+
+func TestUnifiedUserAPIFindIgnoresLeads(t *testing.T) {
+	api := &TestUnifiedContactAPI{contacts: []UnifiedContact{{ID: "1", Role: "lead"}}}
+	users := UserService{Repository: NewUnifiedUserAPI(api)}
+	if _, err := users.FindByID(context.Background(), "1"); !isNotFound(err) {
+		t.Errorf("Error was %v, expected not found", err)
+	}
+	contacts := ContactService{Repository: NewUnifiedLeadAPI(api)}
+	if lead, err := contacts.FindByID(context.Background(), "1"); err != nil || lead.ID != "1" {
+		t.Errorf("Lead was %v, error %v", lead, err)
+	}
+}
+
+func TestUnifiedDeleteChecksRole(t *testing.T) {
+	api := &TestUnifiedContactAPI{contacts: []UnifiedContact{{ID: "1", Role: "lead"}, {ID: "2", Role: "user"}}}
+	users := UserService{Repository: NewUnifiedUserAPI(api)}
+	contacts := ContactService{Repository: NewUnifiedLeadAPI(api)}
+	ctx := context.Background()
+	if _, err := users.Delete(ctx, "1"); !isNotFound(err) {
+		t.Errorf("Deleting a lead as a User, error was %v, expected not found", err)
+	}
+	if _, err := contacts.Delete(ctx, &Contact{ID: "2"}); !isNotFound(err) {
+		t.Errorf("Deleting a user as a Contact, error was %v, expected not found", err)
+	}
+	if len(api.contacts) != 2 {
+		t.Fatalf("Contacts were %v, expected none deleted", api.contacts)
+	}
+	if user, err := users.Delete(ctx, "2"); err != nil || user.ID != "2" {
+		t.Errorf("Deleted User was %v, error %v", user, err)
+	}
+	if lead, err := contacts.Delete(ctx, &Contact{ID: "1"}); err != nil || lead.ID != "1" {
+		t.Errorf("Deleted Contact was %v, error %v", lead, err)
+	}
+}
+
+func TestUnifiedUserAPIList(t *testing.T) {
+	api := &TestUnifiedContactAPI{}
+	for i := 1; i <= 5; i++ {
+		api.contacts = append(api.contacts, UnifiedContact{ID: fmt.Sprint(i), Role: "user"})
+	}
+	api.contacts = append(api.contacts, UnifiedContact{ID: "lead", Role: "lead"})
+	users := UserService{Repository: NewUnifiedUserAPI(api)}
+
+	userList, err := users.List(context.Background(), PageParams{Page: 3, PerPage: 2})
+	if err != nil || len(userList.Users) != 1 || userList.Users[0].ID != "5" {
+		t.Errorf("Users were %v, error %v", userList.Users, err)
+	}
+	if userList.Pages.Page != 3 || userList.Pages.TotalPages != 3 {
+		t.Errorf("Pages were %+v", userList.Pages)
+	}
+	ids := []string{}
+	it := users.IterateScroll(context.Background())
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("Scrolled %v", ids)
+	}
+}
+
+func TestUnifiedUserAPIListFollowsCursor(t *testing.T) {
+	api := &TestUnifiedContactAPI{}
+	for i := 1; i <= 5; i++ {
+		api.contacts = append(api.contacts, UnifiedContact{ID: fmt.Sprint(i), Role: "user"})
+	}
+	users := UserService{Repository: NewUnifiedUserAPI(api)}
+
+	first, _ := users.List(context.Background(), PageParams{PerPage: 2})
+	second, err := users.List(context.Background(), PageParams{PerPage: 2, Next: first.Pages.Next})
+	if err != nil || second.Pages.Page != 2 || len(second.Users) != 2 || second.Users[0].ID != "3" || api.searches != 2 {
+		t.Errorf("Users were %v, pages %+v, error %v, after %d searches", second.Users, second.Pages, err, api.searches)
+	}
+
+	api.searches = 0
+	ids := []string{}
+	it := users.IterateList(context.Background(), PageParams{PerPage: 2})
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" || api.searches != 3 {
+		t.Errorf("Iterated %v in %d searches, expected 3", ids, api.searches)
+	}
+}
+
+func TestUnifiedLeadAPIConvert(t *testing.T) {
+	api := &TestUnifiedContactAPI{contacts: []UnifiedContact{{ID: "1", Role: "lead"}}}
+	contacts := ContactService{Repository: NewUnifiedLeadAPI(api)}
+	user, err := contacts.Convert(context.Background(), &Contact{ID: "1"}, &User{UserID: "27"})
+	if err != nil || user.UserID != "27" {
+		t.Errorf("User was %v, error %v", user, err)
+	}
+	if len(api.contacts) != 1 || !api.contacts[0].IsUser() {
+		t.Errorf("Contacts were %v, expected the lead merged into a new user", api.contacts)
+	}
+}
+
+func TestUnifiedContactConversions(t *testing.T) {
+	user := User{ID: "1", UserID: "27", Email: "jamie@example.io", LastRequestAt: 100}
+	contact := user.UnifiedContact()
+	if !contact.IsUser() || contact.ExternalID != "27" || contact.LastSeenAt != 100 {
+		t.Errorf("Contact was %v", contact)
+	}
+	if back := contact.User(); back.ID != user.ID || back.UserID != user.UserID || back.LastRequestAt != 100 {
+		t.Errorf("User was %v", back)
+	}
+	if lead := (Contact{ID: "2"}).UnifiedContact(); !lead.IsLead() || lead.MessageAddress().Type != "contact" {
+		t.Errorf("Lead was %v", lead)
+	}
+}
+
+// TestUnifiedContactAPI keeps Contacts in memory, searching by AND of equality filters.
+type TestUnifiedContactAPI struct {
+	contacts []UnifiedContact
+	nextID   int
+	searches int
+}
+
+func (t *TestUnifiedContactAPI) index(id string) int {
+	for i := range t.contacts {
+		if t.contacts[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *TestUnifiedContactAPI) find(ctx context.Context, id string) (UnifiedContact, error) {
+	if i := t.index(id); i >= 0 {
+		return t.contacts[i], nil
+	}
+	return UnifiedContact{}, unifiedAdapter{}.notFound("/contacts/" + id)
+}
+
+func (t *TestUnifiedContactAPI) search(ctx context.Context, search *SearchRequest) (UnifiedContactList, error) {
+	t.searches++
+	matched := []UnifiedContact{}
+	for _, contact := range t.contacts {
+		fields := map[string]string{"role": contact.Role, "external_id": contact.ExternalID, "email": contact.Email}
+		match := true
+		for _, q := range search.Query.Value.([]SearchQuery) {
+			match = match && fields[q.Field] == q.Value
+		}
+		if match {
+			matched = append(matched, contact)
+		}
+	}
+	start := 0
+	if search.Pagination.StartingAfter != "" {
+		fmt.Sscan(search.Pagination.StartingAfter, &start)
+	}
+	end := start + int(search.Pagination.PerPage)
+	list := UnifiedContactList{TotalCount: int64(len(matched))}
+	if end < len(matched) {
+		list.Pages.Next = &PageCursor{StartingAfter: fmt.Sprint(end)}
+	} else {
+		end = len(matched)
+	}
+	list.Contacts = matched[start:end]
+	return list, nil
+}
+
+func (t *TestUnifiedContactAPI) create(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	t.nextID++
+	created := *contact
+	created.ID = fmt.Sprintf("new-%d", t.nextID)
+	t.contacts = append(t.contacts, created)
+	return created, nil
+}
+
+func (t *TestUnifiedContactAPI) update(ctx context.Context, contact *UnifiedContact) (UnifiedContact, error) {
+	i := t.index(contact.ID)
+	if i < 0 {
+		return UnifiedContact{}, unifiedAdapter{}.notFound("/contacts/" + contact.ID)
+	}
+	// Like the API, fields that are not sent are left unchanged.
+	updated := &t.contacts[i]
+	for _, field := range []struct{ to, from *string }{
+		{&updated.Role, &contact.Role}, {&updated.ExternalID, &contact.ExternalID},
+		{&updated.Email, &contact.Email}, {&updated.Name, &contact.Name},
+	} {
+		if *field.from != "" {
+			*field.to = *field.from
+		}
+	}
+	return *updated, nil
+}
+
+func (t *TestUnifiedContactAPI) archive(ctx context.Context, id string) (UnifiedContact, error) {
+	return UnifiedContact{ID: id, Archived: true}, nil
+}
+
+func (t *TestUnifiedContactAPI) unarchive(ctx context.Context, id string) (UnifiedContact, error) {
+	return UnifiedContact{ID: id}, nil
+}
+
+func (t *TestUnifiedContactAPI) merge(ctx context.Context, leadID, userID string) (UnifiedContact, error) {
+	t.delete(ctx, leadID)
+	return t.find(ctx, userID)
+}
+
+func (t *TestUnifiedContactAPI) delete(ctx context.Context, id string) (UnifiedContact, error) {
+	i := t.index(id)
+	if i < 0 {
+		return UnifiedContact{}, unifiedAdapter{}.notFound("/contacts/" + id)
+	}
+	deleted := t.contacts[i]
+	t.contacts = append(t.contacts[:i], t.contacts[i+1:]...)
+	deleted.Deleted = true
+	return deleted, nil
+}
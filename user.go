@@ -8,6 +8,8 @@ import (
 // UserService handles interactions with the API through a UserRepository.
 type UserService struct {
 	Repository UserRepository
+	// DataAttributes, if set, validates custom attributes before they are saved.
+	DataAttributes *DataAttributeService
 }
 
 // UserList holds a list of Users and paging information
@@ -127,6 +129,22 @@ func (u *UserService) Scroll(ctx context.Context, scrollParam string) (UserList,
 	return u.Repository.scroll(ctx, scrollParam)
 }
 
+// IterateList iterates over all Users for App, fetching each page from params.Page onwards.
+func (u *UserService) IterateList(ctx context.Context, params PageParams) *Iterator[User] {
+	return newPageIterator(ctx, params, func(ctx context.Context, params PageParams) ([]User, PageParams, error) {
+		userList, err := u.List(ctx, params)
+		return userList.Users, userList.Pages, err
+	})
+}
+
+// IterateScroll iterates over all Users for App via Scroll API.
+func (u *UserService) IterateScroll(ctx context.Context) *Iterator[User] {
+	return newScrollIterator(ctx, func(ctx context.Context, scrollParam string) ([]User, string, error) {
+		userList, err := u.Scroll(ctx, scrollParam)
+		return userList.Users, userList.ScrollParam, err
+	})
+}
+
 // List Users by Segment.
 func (u *UserService) ListBySegment(ctx context.Context, segmentID string, params PageParams) (UserList, error) {
 	return u.Repository.list(ctx, userListParams{PageParams: params, SegmentID: segmentID})
@@ -139,6 +157,11 @@ func (u *UserService) ListByTag(ctx context.Context, tagID string, params PagePa
 
 // Save a User, creating or updating them.
 func (u *UserService) Save(ctx context.Context, user *User) (User, error) {
+	if u.DataAttributes != nil {
+		if err := u.DataAttributes.ValidateCustomAttributes(ctx, MODEL_CONTACT, user.CustomAttributes); err != nil {
+			return User{}, err
+		}
+	}
 	return u.Repository.save(ctx, user)
 }
 
@@ -45,6 +45,7 @@ type requestUser struct {
 }
 
 func (api UserAPI) find(ctx context.Context, params UserIdentifiers) (User, error) {
+	ctx = interfaces.WithOperation(ctx, "users", "find")
 	return unmarshalToUser(api.getClientForFind(ctx, params))
 }
 
@@ -59,6 +60,7 @@ func (api UserAPI) getClientForFind(ctx context.Context, params UserIdentifiers)
 }
 
 func (api UserAPI) list(ctx context.Context, params userListParams) (UserList, error) {
+	ctx = interfaces.WithOperation(ctx, "users", "list")
 	userList := UserList{}
 	data, err := api.httpClient.Get(ctx, "/users", params)
 	if err != nil {
@@ -69,6 +71,7 @@ func (api UserAPI) list(ctx context.Context, params userListParams) (UserList, e
 }
 
 func (api UserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
+	ctx = interfaces.WithOperation(ctx, "users", "scroll")
 	userList := UserList{}
 
 	url := "/users/scroll"
@@ -83,6 +86,7 @@ func (api UserAPI) scroll(ctx context.Context, scrollParam string) (UserList, er
 }
 
 func (api UserAPI) save(ctx context.Context, user *User) (User, error) {
+	ctx = interfaces.WithOperation(ctx, "users", "save")
 	return unmarshalToUser(api.httpClient.Post(ctx, "/users", RequestUserMapper{}.ConvertUser(user)))
 }
 
@@ -96,6 +100,7 @@ func unmarshalToUser(data []byte, err error) (User, error) {
 }
 
 func (api UserAPI) delete(ctx context.Context, id string) (User, error) {
+	ctx = interfaces.WithOperation(ctx, "users", "delete")
 	user := User{}
 	data, err := api.httpClient.Delete(ctx, fmt.Sprintf("/users/%s", id), nil)
 	if err != nil {
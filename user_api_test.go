@@ -1,6 +1,7 @@
 package intercom
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 )
@@ -8,7 +9,7 @@ import (
 func TestUserAPIFind(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/user.json", expectedURI: "/users/54c42e7ea7a765fa7", t: t}
 	api := UserAPI{httpClient: &http}
-	user, err := api.find(UserIdentifiers{ID: "54c42e7ea7a765fa7"})
+	user, err := api.find(context.Background(), UserIdentifiers{ID: "54c42e7ea7a765fa7"})
 	if err != nil {
 		t.Errorf("Error parsing fixture %s", err)
 	}
@@ -32,7 +33,7 @@ func TestUserAPIFind(t *testing.T) {
 func TestUserAPIFindByEmail(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/user.json", expectedURI: "/users", t: t}
 	api := UserAPI{httpClient: &http}
-	user, _ := api.find(UserIdentifiers{Email: "myuser@example.io"})
+	user, _ := api.find(context.Background(), UserIdentifiers{Email: "myuser@example.io"})
 	if user.Email != "myuser@example.io" {
 		t.Errorf("Email was %s, expected myuser@example.io", user.Email)
 	}
@@ -41,7 +42,7 @@ func TestUserAPIFindByEmail(t *testing.T) {
 func TestUserAPIListDefault(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/users.json", expectedURI: "/users", t: t}
 	api := UserAPI{httpClient: &http}
-	userList, _ := api.list(userListParams{})
+	userList, _ := api.list(context.Background(), userListParams{})
 	users := userList.Users
 	if users[0].ID != "54c42e7ea7a765fa7" {
 		t.Errorf("ID was %s, expected 54c42e7ea7a765fa7", users[0].ID)
@@ -50,12 +51,15 @@ func TestUserAPIListDefault(t *testing.T) {
 	if pages.Page != 1 {
 		t.Errorf("Page was %d, expected 1", pages.Page)
 	}
+	if pages.Next == nil || pages.Next.URL != "https://api.intercom.io/users?per_page=50&page=2" {
+		t.Errorf("Next page was %v", pages.Next)
+	}
 }
 
 func TestUserAPIListWithPageNumber(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/users_page_2.json", expectedURI: "/users", t: t}
 	api := UserAPI{httpClient: &http}
-	userList, _ := api.list(userListParams{PageParams: PageParams{Page: 2}})
+	userList, _ := api.list(context.Background(), userListParams{PageParams: PageParams{Page: 2}})
 	pages := userList.Pages
 	if pages.Page != 2 {
 		t.Errorf("Page was %d, expected 2", pages.Page)
@@ -65,7 +69,7 @@ func TestUserAPIListWithPageNumber(t *testing.T) {
 func TestUserAPIListWithSegment(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/users.json", expectedURI: "/users", t: t}
 	api := UserAPI{httpClient: &http}
-	api.list(userListParams{SegmentID: "abc123"})
+	api.list(context.Background(), userListParams{SegmentID: "abc123"})
 	if ulParams, ok := http.lastQueryParams.(userListParams); !ok || ulParams.SegmentID != "abc123" {
 		t.Errorf("SegmentID expected to be abc123, but was %s", ulParams.SegmentID)
 	}
@@ -74,7 +78,7 @@ func TestUserAPIListWithSegment(t *testing.T) {
 func TestUserAPIListWithTag(t *testing.T) {
 	http := TestUserHTTPClient{fixtureFilename: "fixtures/users.json", expectedURI: "/users", t: t}
 	api := UserAPI{httpClient: &http}
-	api.list(userListParams{TagID: "123"})
+	api.list(context.Background(), userListParams{TagID: "123"})
 	if ulParams, ok := http.lastQueryParams.(userListParams); !ok || ulParams.TagID != "123" {
 		t.Errorf("SegmentID expected to be 123, but was %s", ulParams.TagID)
 	}
@@ -89,13 +93,13 @@ func TestUserAPISave(t *testing.T) {
 		},
 	}
 	user := User{UserID: "27", Companies: &companyList}
-	api.save(&user)
+	api.save(context.Background(), &user)
 }
 
 func TestUserAPIDelete(t *testing.T) {
 	http := TestUserHTTPClient{t: t, expectedURI: "/users/1234"}
 	api := UserAPI{httpClient: &http}
-	api.delete("1234")
+	api.delete(context.Background(), "1234")
 }
 
 type TestUserHTTPClient struct {
@@ -106,7 +110,7 @@ type TestUserHTTPClient struct {
 	lastQueryParams interface{}
 }
 
-func (t *TestUserHTTPClient) Get(uri string, queryParams interface{}) ([]byte, error) {
+func (t *TestUserHTTPClient) Get(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
 	}
@@ -114,14 +118,14 @@ func (t *TestUserHTTPClient) Get(uri string, queryParams interface{}) ([]byte, e
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t *TestUserHTTPClient) Post(uri string, body interface{}) ([]byte, error) {
+func (t *TestUserHTTPClient) Post(ctx context.Context, uri string, body interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("Wrong endpoint called")
 	}
 	return ioutil.ReadFile(t.fixtureFilename)
 }
 
-func (t *TestUserHTTPClient) Delete(uri string, queryParams interface{}) ([]byte, error) {
+func (t *TestUserHTTPClient) Delete(ctx context.Context, uri string, queryParams interface{}) ([]byte, error) {
 	if t.expectedURI != uri {
 		t.t.Errorf("URI was %s, expected %s", uri, t.expectedURI)
 	}
@@ -1,32 +1,33 @@
 package intercom
 
 import (
+	"context"
 	"testing"
 )
 
 func TestUserFindByID(t *testing.T) {
-	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByID("46adad3f09126dca")
+	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByID(context.Background(), "46adad3f09126dca")
 	if user.ID != "46adad3f09126dca" {
 		t.Errorf("User not found")
 	}
 }
 
 func TestUserFindByEmail(t *testing.T) {
-	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByEmail("jamie@example.io")
+	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByEmail(context.Background(), "jamie@example.io")
 	if user.Email != "jamie@example.io" {
 		t.Errorf("User not found")
 	}
 }
 
 func TestUserFindByUserID(t *testing.T) {
-	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByUserID("134d")
+	user, _ := (&UserService{Repository: TestUserAPI{t: t}}).FindByUserID(context.Background(), "134d")
 	if user.UserID != "134d" {
 		t.Errorf("User not found")
 	}
 }
 
 func TestUserList(t *testing.T) {
-	userList, _ := (&UserService{Repository: TestUserAPI{t: t}}).List(PageParams{})
+	userList, _ := (&UserService{Repository: TestUserAPI{t: t}}).List(context.Background(), PageParams{})
 	users := userList.Users
 	if users[0].ID != "46adad3f09126dca" {
 		t.Errorf("User not listed")
@@ -36,11 +37,11 @@ func TestUserList(t *testing.T) {
 func TestUserSave(t *testing.T) {
 	userService := UserService{Repository: TestUserAPI{t: t}}
 	user := User{ID: "46adad3f09126dca", CustomAttributes: map[string]interface{}{"is_cool": true}}
-	userService.Save(&user)
+	userService.Save(context.Background(), &user)
 }
 
 func TestUserDelete(t *testing.T) {
-	(&UserService{Repository: TestUserAPI{t: t}}).Delete("46adad3f09126dca")
+	(&UserService{Repository: TestUserAPI{t: t}}).Delete(context.Background(), "46adad3f09126dca")
 }
 
 func TestUserMessageAddress(t *testing.T) {
@@ -64,19 +65,19 @@ type TestUserAPI struct {
 	t *testing.T
 }
 
-func (t TestUserAPI) find(params UserIdentifiers) (User, error) {
+func (t TestUserAPI) find(ctx context.Context, params UserIdentifiers) (User, error) {
 	return User{ID: params.ID, Email: params.Email, UserID: params.UserID}, nil
 }
 
-func (t TestUserAPI) list(params userListParams) (UserList, error) {
+func (t TestUserAPI) list(ctx context.Context, params userListParams) (UserList, error) {
 	return UserList{Users: []User{User{ID: "46adad3f09126dca", Email: "jamie@example.io", UserID: "aa123"}}}, nil
 }
 
-func (t TestUserAPI) scroll(scrollParam string) (UserList, error) {
+func (t TestUserAPI) scroll(ctx context.Context, scrollParam string) (UserList, error) {
 	return UserList{Users: []User{User{ID: "46adad3f09126dca", Email: "jamie@example.io", UserID: "aa123"}}}, nil
 }
 
-func (t TestUserAPI) save(user *User) (User, error) {
+func (t TestUserAPI) save(ctx context.Context, user *User) (User, error) {
 	if user.ID != "46adad3f09126dca" {
 		t.t.Errorf("User ID was %s, expected 46adad3f09126dca", user.ID)
 	}
@@ -87,7 +88,7 @@ func (t TestUserAPI) save(user *User) (User, error) {
 	return User{}, nil
 }
 
-func (t TestUserAPI) delete(id string) (User, error) {
+func (t TestUserAPI) delete(ctx context.Context, id string) (User, error) {
 	if id != "46adad3f09126dca" {
 		t.t.Errorf("id was %s, expected 46adad3f09126dca", id)
 	}
@@ -0,0 +1,18 @@
+package intercom
+
+import "github.com/opensimsim/intercom-go/interfaces"
+
+// versionedHTTPClient is implemented by HTTPClients that pin an Intercom-Version.
+type versionedHTTPClient interface {
+	APIVersion() string
+}
+
+// requireVersion checks the HTTPClient's pinned API version is at least the required version
+// before calling an endpoint. HTTPClients that do not pin a version are not checked.
+func requireVersion(httpClient interfaces.HTTPClient, required, method, path string) error {
+	versioned, ok := httpClient.(versionedHTTPClient)
+	if !ok {
+		return nil
+	}
+	return interfaces.RequireVersion(versioned.APIVersion(), required, method, path)
+}
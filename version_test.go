@@ -0,0 +1,67 @@
+package intercom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/opensimsim/intercom-go/interfaces"
+)
+
+func TestRequireVersion(t *testing.T) {
+	ic := NewClient("appID", "apiKey")
+	if err := requireVersion(ic.HTTPClient, "2.0", "POST", "/conversations/search"); err != nil {
+		t.Errorf("Error was %v, expected none without a pinned version", err)
+	}
+	ic.Option(APIVersion("1.4"))
+	err := requireVersion(ic.HTTPClient, "2.0", "POST", "/conversations/search")
+	if _, ok := err.(interfaces.VersionError); !ok {
+		t.Errorf("Error was %v, expected a VersionError", err)
+	}
+}
+
+func TestConversationUnmarshal1x(t *testing.T) {
+	data, _ := ioutil.ReadFile("fixtures/conversation.json")
+	convo := Conversation{}
+	if err := json.Unmarshal(data, &convo); err != nil {
+		t.Fatalf("Error was %v, expected none", err)
+	}
+	if convo.Assignee.ID != "25" || convo.User.ID != "536e564f316c83104c000020" || convo.ConversationMessage.ID != "537e564f316c33104c010020" {
+		t.Errorf("Conversation was %+v", convo)
+	}
+}
+
+func TestConversationUnmarshal2x(t *testing.T) {
+	data := []byte(`{
+		"type": "conversation",
+		"id": "147",
+		"state": "open",
+		"admin_assignee_id": 25,
+		"team_assignee_id": 814865,
+		"source": {"type": "conversation", "id": "403918316", "body": "<p>Hi</p>", "author": {"type": "admin", "id": "25"}},
+		"contacts": {"type": "contact.list", "contacts": [{"type": "contact", "id": "5bc8f7ae2d96695c18a"}]},
+		"conversation_parts": {"type": "conversation_part.list", "conversation_parts": [{"id": "4412", "assigned_to": {"type": "admin", "id": 26}}]}
+	}`)
+	convo := Conversation{}
+	if err := json.Unmarshal(data, &convo); err != nil {
+		t.Fatalf("Error was %v, expected none", err)
+	}
+	if !convo.Open {
+		t.Errorf("Conversation was not open")
+	}
+	if convo.Assignee.ID != "25" {
+		t.Errorf("Assignee was %s, expected 25", convo.Assignee.ID)
+	}
+	if convo.TeamAssigneeID != "814865" {
+		t.Errorf("TeamAssigneeID was %s, expected 814865", convo.TeamAssigneeID)
+	}
+	if convo.ConversationMessage.ID != "403918316" {
+		t.Errorf("ConversationMessage was %v", convo.ConversationMessage)
+	}
+	if convo.User.ID != "5bc8f7ae2d96695c18a" {
+		t.Errorf("User was %s, expected 5bc8f7ae2d96695c18a", convo.User.ID)
+	}
+	if convo.ConversationParts.Parts[0].AssignedTo.ID != "26" {
+		t.Errorf("Part AssignedTo was %s, expected 26", convo.ConversationParts.Parts[0].AssignedTo.ID)
+	}
+}
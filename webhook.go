@@ -0,0 +1,89 @@
+package intercom
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxWebhookBodySize limits the size of webhook requests read by WebhookHandler.
+const maxWebhookBodySize = 1 << 20
+
+// A NotificationHandlerFunc handles a webhook Notification.
+// Returning an error responds with a 500, so that Intercom will retry delivery.
+type NotificationHandlerFunc func(r *http.Request, notification *Notification) error
+
+// WebhookHandler is an http.Handler that receives webhook Notifications from Intercom,
+// verifying their X-Hub-Signature before dispatching them to the callback for their topic.
+// Notifications without a callback, including Intercom's "ping", are acknowledged and otherwise ignored.
+type WebhookHandler struct {
+	secret    []byte
+	mu        sync.RWMutex
+	callbacks map[string]NotificationHandlerFunc
+}
+
+// NewWebhookHandler creates a WebhookHandler that verifies notifications were signed with secret,
+// the hub secret (typically your app's client secret) configured for the webhook.
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{secret: []byte(secret), callbacks: map[string]NotificationHandlerFunc{}}
+}
+
+// On registers the callback for Notifications with the given topic, such as "conversation.user.created".
+func (h *WebhookHandler) On(topic string, fn NotificationHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[topic] = fn
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if !VerifyWebhookSignature(h.secret, body, r.Header.Get("X-Hub-Signature")) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	notification, err := NewNotification(bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	fn := h.callbacks[notification.Topic]
+	h.mu.RUnlock()
+	if fn != nil {
+		if err := fn(r, notification); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// VerifyWebhookSignature checks an X-Hub-Signature header ("sha1=<hex digest>")
+// is the HMAC-SHA1 of body using secret.
+func VerifyWebhookSignature(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha1=") {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha1="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
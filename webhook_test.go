@@ -0,0 +1,94 @@
+package intercom
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func serveWebhook(h *WebhookHandler, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewReader(body))
+	req.Header.Set("X-Hub-Signature", signature)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookDispatchesToTopic(t *testing.T) {
+	body, _ := ioutil.ReadFile("fixtures/notification.json")
+	h := NewWebhookHandler("s3cret")
+	var received *Notification
+	h.On("company.created", func(r *http.Request, n *Notification) error {
+		received = n
+		return nil
+	})
+	h.On("user.created", func(r *http.Request, n *Notification) error {
+		t.Errorf("Wrong callback called")
+		return nil
+	})
+	w := serveWebhook(h, body, signWebhook("s3cret", body))
+	if w.Code != http.StatusOK {
+		t.Errorf("Status was %d, expected 200", w.Code)
+	}
+	if received == nil || received.Company == nil || received.Company.Name != "Blue Sun" {
+		t.Errorf("Notification was %v, expected company.created for Blue Sun", received)
+	}
+}
+
+func TestWebhookRejectsBadSignature(t *testing.T) {
+	body, _ := ioutil.ReadFile("fixtures/notification.json")
+	h := NewWebhookHandler("s3cret")
+	h.On("company.created", func(r *http.Request, n *Notification) error {
+		t.Errorf("Callback called with bad signature")
+		return nil
+	})
+	for _, signature := range []string{"", "sha1=zz", signWebhook("wrong", body), signWebhook("s3cret", body)[5:]} {
+		if w := serveWebhook(h, body, signature); w.Code != http.StatusUnauthorized {
+			t.Errorf("Status for signature %q was %d, expected 401", signature, w.Code)
+		}
+	}
+}
+
+func TestWebhookAnswersPing(t *testing.T) {
+	body := []byte(`{"type":"notification_event","topic":"ping","data":{"item":{"type":"ping","message":"something something interzen"}}}`)
+	h := NewWebhookHandler("s3cret")
+	if w := serveWebhook(h, body, signWebhook("s3cret", body)); w.Code != http.StatusOK {
+		t.Errorf("Status was %d, expected 200", w.Code)
+	}
+}
+
+func TestWebhookCallbackError(t *testing.T) {
+	body, _ := ioutil.ReadFile("fixtures/notification.json")
+	h := NewWebhookHandler("s3cret")
+	h.On("company.created", func(r *http.Request, n *Notification) error {
+		return errors.New("database down")
+	})
+	if w := serveWebhook(h, body, signWebhook("s3cret", body)); w.Code != http.StatusInternalServerError {
+		t.Errorf("Status was %d, expected 500", w.Code)
+	}
+}
+
+func TestWebhookRejectsBadRequests(t *testing.T) {
+	h := NewWebhookHandler("s3cret")
+	body := []byte(`not json`)
+	if w := serveWebhook(h, body, signWebhook("s3cret", body)); w.Code != http.StatusBadRequest {
+		t.Errorf("Status was %d, expected 400", w.Code)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Status was %d, expected 405", w.Code)
+	}
+}